@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -55,3 +57,79 @@ func TestClientEndToEnd(t *testing.T) {
 	_, err = cmd.CombinedOutput()
 	assert.Error(t, err)
 }
+
+func TestClientWorkbook(t *testing.T) {
+	data, err := os.ReadFile("../../test/data/validWorkbook.xlsx")
+	assert.Nil(t, err)
+	workbook := filepath.Join(t.TempDir(), "workbook.xlsx")
+	assert.Nil(t, os.WriteFile(workbook, data, 0644))
+
+	cmd := exec.Command("go", "run", "./main.go", "--write-back")
+	_, err = cmd.CombinedOutput()
+	assert.Error(t, err)
+
+	cmd = exec.Command("go", "run", "./main.go", "--workbook="+workbook, "--responses-sheet=Missing", "--write-back")
+	_, err = cmd.CombinedOutput()
+	assert.Error(t, err)
+
+	cmd = exec.Command("go", "run", "./main.go", "--workbook="+workbook, "--worksheet=../../test/data/validWs.csv", "--write-back")
+	_, err = cmd.CombinedOutput()
+	assert.Error(t, err)
+
+	cmd = exec.Command("go", "run", "./main.go", "--workbook="+workbook, "--results-sheet=responses", "--write-back")
+	_, err = cmd.CombinedOutput()
+	assert.Error(t, err)
+
+	cmd = exec.Command("go", "run", "./main.go", "--workbook="+workbook, "--results-sheet=Metadata", "--write-back")
+	_, err = cmd.CombinedOutput()
+	assert.Error(t, err)
+
+	cmd = exec.Command("go", "run", "./main.go", "--workbook=../../test/data/validWs.csv", "--output="+filepath.Join(t.TempDir(), "results.csv"))
+	_, err = cmd.CombinedOutput()
+	assert.Error(t, err)
+
+	cmd = exec.Command("go", "run", "./main.go", "--workbook="+workbook, "--write-back")
+	out, err := cmd.CombinedOutput()
+	assert.Nil(t, err, string(out))
+}
+
+func TestClientGenerateVariants(t *testing.T) {
+	dir := t.TempDir()
+	roster := filepath.Join(dir, "roster.csv")
+	output := filepath.Join(dir, "worksheet.csv")
+
+	assert.Nil(t, os.WriteFile(roster, []byte("Ada\nA.B\nA B\n"), 0644))
+	cmd := exec.Command("go", "run", "./main.go", "generate", "--students="+roster, "--output="+output)
+	_, err := cmd.CombinedOutput()
+	assert.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "worksheet-ada.csv"))
+
+	assert.Nil(t, os.WriteFile(roster, []byte("Ada\n!!!\n"), 0644))
+	cmd = exec.Command("go", "run", "./main.go", "generate", "--students="+roster, "--output="+output)
+	_, err = cmd.CombinedOutput()
+	assert.Error(t, err)
+
+	assert.Nil(t, os.WriteFile(roster, []byte("Ada\nA.B\n"), 0644))
+	cmd = exec.Command("go", "run", "./main.go", "generate", "--students="+roster, "--output="+output)
+	out, err := cmd.CombinedOutput()
+	assert.Nil(t, err, string(out))
+	assert.FileExists(t, filepath.Join(dir, "worksheet-a-b.csv"))
+
+	// variants are graded against generated worksheets only
+	cmd = exec.Command("go", "run", "./main.go", "--variants", "--seed=1", "--worksheet=../../test/data/validWs.csv",
+		"--responses=../../test/data/validResponses.csv", "--output="+filepath.Join(dir, "results.csv"))
+	_, err = cmd.CombinedOutput()
+	assert.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "results.csv"))
+}
+
+func TestClientQuery(t *testing.T) {
+	cmd := exec.Command("go", "run", "./main.go", "query", "How many cups are in 3 liters?")
+	out, err := cmd.Output()
+	assert.Nil(t, err)
+	assert.Equal(t, "3 liters = 12.7 cups\n", string(out))
+
+	cmd = exec.Command("go", "run", "./main.go", "query", "Convert 100 to Celsius")
+	_, err = cmd.CombinedOutput()
+	assert.Error(t, err)
+}
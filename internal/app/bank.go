@@ -0,0 +1,276 @@
+package app
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+type Difficulty string
+
+const (
+	Easy   Difficulty = "easy"
+	Medium Difficulty = "medium"
+	Hard   Difficulty = "hard"
+)
+
+// DefaultDifficulty is the difficulty of bank questions that are not tagged with one.
+const DefaultDifficulty = Medium
+
+var difficulties = map[Difficulty]struct{}{
+	Easy:   {},
+	Medium: {},
+	Hard:   {},
+}
+
+func ParseDifficulty(s string) (Difficulty, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return DefaultDifficulty, nil
+	}
+	if _, ok := difficulties[Difficulty(s)]; !ok {
+		return "", fmt.Errorf("unknown difficulty '%s': use easy, medium or hard", s)
+	}
+	return Difficulty(s), nil
+}
+
+// bankQuestionColumns are the worksheet columns a bank keeps for its questions,
+// in the order they are written.
+var bankQuestionColumns = []string{
+	IDColumn, TextColumn, InputColumn, FromUnitColumn, ToUnitColumn, TypeColumn, ChoicesColumn,
+	ToleranceColumn, PointsColumn, ExpectedAnswerColumn, RoundStepsColumn,
+	AllowImpossibleColumn,
+}
+
+var bankTagColumns = []string{DimensionColumn, DifficultyColumn, TopicColumn}
+
+// Bank is a reusable collection of tagged questions. It is stored as a
+// worksheet file with Dimension, Difficulty and Topic columns, so a bank file
+// can be checked like any other worksheet.
+type Bank struct {
+	Entries []BankEntry
+}
+
+type BankEntry struct {
+	Dimension  string
+	Difficulty Difficulty
+	Topic      string
+
+	id       string
+	identity string            // equal for entries that ask the same thing
+	values   map[string]string // worksheet column values of the question
+}
+
+// NewBank reads a bank file. An empty file is an empty bank.
+func NewBank(data [][]string) (Bank, error) {
+	bank := Bank{}
+	if len(data) == 0 {
+		return bank, nil
+	}
+	if err := bank.Add(data, DefaultDifficulty, ""); err != nil {
+		return Bank{}, err
+	}
+	return bank, nil
+}
+
+// Add adds the questions of worksheet rows to the bank. Questions without tags
+// of their own are tagged with difficulty and topic, and with the dimension of
+// their From Unit. Questions already in the bank are skipped.
+func (b *Bank) Add(data [][]string, difficulty Difficulty, topic string) error {
+	_, data, err := parseMetadata(data)
+	if err != nil {
+		return err
+	}
+	layout, rows, _, err := detectLayout(data)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]struct{}, len(b.Entries))
+	ids := make(map[string]struct{}, len(b.Entries))
+	for _, entry := range b.Entries {
+		seen[entry.identity] = struct{}{}
+		if entry.id != "" {
+			ids[strings.ToLower(entry.id)] = struct{}{}
+		}
+	}
+
+	for _, row := range rows {
+		entry, err := newBankEntry(row, layout, difficulty, topic)
+		if err != nil {
+			return err
+		}
+		if _, ok := seen[entry.identity]; ok {
+			continue
+		}
+		if id := strings.ToLower(entry.id); id != "" {
+			if _, ok := ids[id]; ok {
+				return fmt.Errorf("duplicate question ID '%s'", entry.id)
+			}
+			ids[id] = struct{}{}
+		}
+		seen[entry.identity] = struct{}{}
+		b.Entries = append(b.Entries, entry)
+	}
+	return nil
+}
+
+func newBankEntry(row []string, layout columnLayout, difficulty Difficulty, topic string) (BankEntry, error) {
+	row, layout, err := expandWordProblem(row, layout)
+	if err != nil {
+		return BankEntry{}, err
+	}
+	questions, err := buildQuestions(row, layout, TableConverter{}, roundFunc)
+	if err != nil {
+		return BankEntry{}, err
+	}
+	for _, step := range questions {
+		if step.CorrectAnswer == nil {
+			return BankEntry{}, fmt.Errorf("question cannot be answered: %s", strings.Join(row, ","))
+		}
+	}
+	q := questions[0]
+
+	entry := BankEntry{
+		Difficulty: difficulty,
+		Topic:      topic,
+		id:         layout.value(row, IDColumn),
+		identity:   rowIdentity(questions),
+		values:     make(map[string]string),
+	}
+	for _, column := range bankQuestionColumns {
+		if value := layout.value(row, column); value != "" {
+			entry.values[column] = value
+		}
+	}
+
+	entry.Dimension, _, _ = lookupUnit(q.InputUoM)
+	if dimension := strings.ToLower(layout.value(row, DimensionColumn)); dimension != "" {
+		if _, ok := unitConversions[dimension]; !ok {
+			return BankEntry{}, &ColumnError{DimensionColumn,
+				fmt.Errorf("unknown dimension '%s' in question: %s", dimension, strings.Join(row, ","))}
+		}
+		entry.Dimension = dimension
+	}
+	if difficultyStr := layout.value(row, DifficultyColumn); difficultyStr != "" {
+		entry.Difficulty, err = ParseDifficulty(difficultyStr)
+		if err != nil {
+			return BankEntry{}, &ColumnError{DifficultyColumn,
+				fmt.Errorf("%v in question: %s", err, strings.Join(row, ","))}
+		}
+	}
+	if topicStr := layout.value(row, TopicColumn); topicStr != "" {
+		entry.Topic = topicStr
+	}
+
+	return entry, nil
+}
+
+// ToGrid writes the bank as a worksheet file with tag columns.
+func (b Bank) ToGrid() [][]string {
+	columns := usedColumns(b.Entries)
+	grid := [][]string{columnLabels(append(columns, bankTagColumns...))}
+	for _, entry := range b.Entries {
+		grid = append(grid, append(entry.row(columns), entry.Dimension, string(entry.Difficulty), entry.Topic))
+	}
+	return grid
+}
+
+type AssembleOptions struct {
+	Dimensions []string // any dimension when empty
+	Difficulty Difficulty
+	Topics     []string // any topic when empty
+	Count      int
+	Seed       int64
+}
+
+// Assemble picks Count random questions matching the options and returns
+// worksheet rows, header included, that NewWorksheet reads.
+func (b Bank) Assemble(opts AssembleOptions) ([][]string, error) {
+	if opts.Count <= 0 {
+		return nil, fmt.Errorf("question count must be positive, got %d", opts.Count)
+	}
+
+	matches := make([]BankEntry, 0)
+	for _, entry := range b.Entries {
+		if entry.matches(opts) {
+			matches = append(matches, entry)
+		}
+	}
+	if len(matches) < opts.Count {
+		return nil, fmt.Errorf("only %d bank question(s) match, %d requested", len(matches), opts.Count)
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	rng.Shuffle(len(matches), func(i, j int) {
+		matches[i], matches[j] = matches[j], matches[i]
+	})
+	matches = matches[:opts.Count]
+
+	columns := usedColumns(matches)
+	grid := [][]string{columnLabels(columns)}
+	for _, entry := range matches {
+		grid = append(grid, entry.row(columns))
+	}
+	return grid, nil
+}
+
+func (e BankEntry) matches(opts AssembleOptions) bool {
+	if opts.Difficulty != "" && e.Difficulty != opts.Difficulty {
+		return false
+	}
+	if len(opts.Dimensions) > 0 && !containsFold(opts.Dimensions, e.Dimension) {
+		return false
+	}
+	if len(opts.Topics) > 0 && !containsFold(opts.Topics, e.Topic) {
+		return false
+	}
+	return true
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimSpace(item), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// usedColumns lists the required worksheet columns and any other column the
+// entries have a value for.
+func usedColumns(entries []BankEntry) []string {
+	columns := make([]string, 0, len(bankQuestionColumns))
+	for _, column := range bankQuestionColumns {
+		used := false
+		for _, entry := range entries {
+			if _, ok := entry.values[column]; ok {
+				used = true
+				break
+			}
+		}
+		for _, required := range requiredColumns {
+			used = used || column == required
+		}
+		if used {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+func (e BankEntry) row(columns []string) []string {
+	row := make([]string, len(columns))
+	for idx, column := range columns {
+		row[idx] = e.values[column]
+	}
+	return row
+}
+
+func columnLabels(columns []string) []string {
+	labels := make([]string, len(columns))
+	for idx, column := range columns {
+		labels[idx] = columnLabel(column)
+	}
+	return labels
+}
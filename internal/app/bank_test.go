@@ -0,0 +1,132 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDifficulty(t *testing.T) {
+	difficulty, err := ParseDifficulty(" Hard ")
+	assert.NoError(t, err)
+	assert.Equal(t, Hard, difficulty)
+
+	difficulty, err = ParseDifficulty("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultDifficulty, difficulty)
+
+	_, err = ParseDifficulty("impossible")
+	assert.Error(t, err)
+}
+
+func TestBankAdd(t *testing.T) {
+	bank, err := NewBank(nil)
+	assert.NoError(t, err)
+
+	err = bank.Add([][]string{
+		{"100", "fahrenheit", "celsius"},
+		{"2", "cups", "liters"},
+	}, Easy, "cooking")
+	assert.NoError(t, err)
+	assert.Len(t, bank.Entries, 2)
+	assert.Equal(t, "temperature", bank.Entries[0].Dimension)
+	assert.Equal(t, "volume", bank.Entries[1].Dimension)
+	assert.Equal(t, Easy, bank.Entries[1].Difficulty)
+	assert.Equal(t, "cooking", bank.Entries[1].Topic)
+
+	// tags given in the rows win, questions already in the bank are skipped
+	err = bank.Add([][]string{
+		{"Input", "From Unit", "To Unit", "Difficulty", "Topic"},
+		{"100", "Fahrenheit", "Celsius", "hard", "weather"},
+		{"300", "kelvin", "celsius", "hard", "weather"},
+	}, Easy, "cooking")
+	assert.NoError(t, err)
+	assert.Len(t, bank.Entries, 3)
+	assert.Equal(t, Hard, bank.Entries[2].Difficulty)
+	assert.Equal(t, "weather", bank.Entries[2].Topic)
+
+	assert.Error(t, bank.Add([][]string{{"100", "fahrenheit", "liters"}}, Easy, ""))
+	assert.Error(t, bank.Add([][]string{{"Input", "From Unit", "To Unit", "Difficulty"}, {"1", "cups", "pints", "tricky"}}, Easy, ""))
+	assert.Error(t, bank.Add([][]string{{"Input", "From Unit", "To Unit", "Dimension"}, {"1", "cups", "pints", "length"}}, Easy, ""))
+	assert.Error(t, bank.Add([][]string{{"ID", "Input", "From Unit", "To Unit"}, {"q1", "1", "cups", "pints"}, {"Q1", "2", "cups", "pints"}}, Easy, ""))
+}
+
+func TestBankToGrid(t *testing.T) {
+	bank, _ := NewBank(nil)
+	bank.Add([][]string{
+		{"Input", "From Unit", "To Unit", "Points"},
+		{"100", "fahrenheit", "celsius", "2"},
+		{"2", "cups", "liters", ""},
+	}, Medium, "cooking")
+
+	grid := bank.ToGrid()
+	assert.Equal(t, [][]string{
+		{"Input", "From Unit", "To Unit", "Points", "Dimension", "Difficulty", "Topic"},
+		{"100", "fahrenheit", "celsius", "2", "temperature", "medium", "cooking"},
+		{"2", "cups", "liters", "", "volume", "medium", "cooking"},
+	}, grid)
+
+	reread, err := NewBank(grid)
+	assert.NoError(t, err)
+	assert.Equal(t, bank.Entries[0].Dimension, reread.Entries[0].Dimension)
+	assert.Equal(t, grid, reread.ToGrid())
+
+	// a bank file is also a valid worksheet
+	ws, err := NewWorksheet(grid)
+	assert.NoError(t, err)
+	assert.Empty(t, ws.UnknownColumns)
+	assert.Len(t, ws.Questions, 2)
+}
+
+func TestBankAssemble(t *testing.T) {
+	bank, _ := NewBank([][]string{
+		{"ID", "Input", "From Unit", "To Unit", "Difficulty", "Topic"},
+		{"t1", "100", "fahrenheit", "celsius", "medium", "weather"},
+		{"t2", "300", "kelvin", "celsius", "medium", "science"},
+		{"t3", "0", "celsius", "fahrenheit", "easy", "weather"},
+		{"v1", "2", "cups", "liters", "medium", "cooking"},
+	})
+
+	rows, err := bank.Assemble(AssembleOptions{Dimensions: []string{"Temperature"}, Difficulty: Medium, Count: 2, Seed: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ID", "Input", "From Unit", "To Unit"}, rows[0])
+	assert.ElementsMatch(t, [][]string{
+		{"t1", "100", "fahrenheit", "celsius"},
+		{"t2", "300", "kelvin", "celsius"},
+	}, rows[1:])
+
+	again, _ := bank.Assemble(AssembleOptions{Dimensions: []string{"Temperature"}, Difficulty: Medium, Count: 2, Seed: 1})
+	assert.Equal(t, rows, again)
+
+	ws, err := NewWorksheet(rows)
+	assert.NoError(t, err)
+	assert.Len(t, ws.Questions, 2)
+
+	rows, err = bank.Assemble(AssembleOptions{Topics: []string{"weather"}, Count: 2})
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+
+	_, err = bank.Assemble(AssembleOptions{Difficulty: Hard, Count: 1})
+	assert.Error(t, err)
+	_, err = bank.Assemble(AssembleOptions{Count: 0})
+	assert.Error(t, err)
+}
+
+func TestBankChainedQuestion(t *testing.T) {
+	bank, err := NewBank([][]string{
+		{"Input", "From Unit", "To Unit", "Round Steps"},
+		{"2", "cups", "tablespoons > cubic inches", "none"},
+		{"2", "cups", "tablespoons > teaspoons", ""},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, bank.Entries, 2)
+
+	rows, err := bank.Assemble(AssembleOptions{Count: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Input", "From Unit", "To Unit", "Round Steps"}, rows[0])
+	ws, err := NewWorksheet(rows)
+	assert.NoError(t, err)
+	assert.Len(t, ws.Questions, 4)
+
+	assert.Error(t, bank.Add([][]string{{"2", "cups", "tablespoons > kelvin"}}, Easy, ""))
+}
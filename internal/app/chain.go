@@ -0,0 +1,102 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// chainSeparator splits the To Unit column of a chained question,
+// e.g. "tablespoons > cubic inches".
+const chainSeparator = ">"
+
+// buildQuestions builds the questions of a worksheet row: one question, or
+// one per step of a chained conversion.
+func buildQuestions(data []string, layout columnLayout, conv Converter, round func(float64) float64) ([]Question, error) {
+	data, layout, err := expandWordProblem(data, layout)
+	if err != nil {
+		return nil, err
+	}
+	q, err := buildQuestion(data, layout, conv, round)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(q.TargetUoM, chainSeparator) {
+		return []Question{q}, nil
+	}
+
+	if q.Type != ConversionQuestion {
+		return nil, &ColumnError{TypeColumn,
+			fmt.Errorf("only conversion questions can be chained: %s", strings.Join(data, ","))}
+	}
+	if q.ExpectedAnswer != nil {
+		return nil, &ColumnError{ExpectedAnswerColumn,
+			fmt.Errorf("expected answers are not supported for chained questions: %s", strings.Join(data, ","))}
+	}
+	units := strings.Split(q.TargetUoM, chainSeparator)
+	roundAfter, err := parseRoundSteps(layout.value(data, RoundStepsColumn), len(units))
+	if err != nil {
+		return nil, &ColumnError{RoundStepsColumn,
+			fmt.Errorf("%v in question: %s", err, strings.Join(data, ","))}
+	}
+
+	return chainQuestions(q, units, roundAfter, conv, round), nil
+}
+
+// chainQuestions converts step by step, each step starting from the answer of
+// the one before: rounded when the step is in roundAfter, exact otherwise.
+// A step that cannot be answered leaves the rest of the chain invalid.
+func chainQuestions(q Question, units []string, roundAfter map[int]bool, conv Converter, round func(float64) float64) []Question {
+	steps := make([]Question, 0, len(units))
+	input, from, uncertainty, valid := q.Input, q.InputUoM, q.Uncertainty, true
+	for idx, unit := range units {
+		step := q
+		step.Step = idx + 1
+		step.Input, step.InputUoM, step.TargetUoM = input, from, strings.TrimSpace(unit)
+		step.Uncertainty = uncertainty
+		if q.ID != "" {
+			step.ID = q.ID + "." + strconv.Itoa(step.Step)
+		}
+
+		if valid {
+			step.setKey(conv, round)
+		} else {
+			step.ComputedAnswer, step.computedExact = nil, 0
+			step.CorrectAnswer, step.exact = nil, 0
+			step.AnswerUncertainty = nil
+			step.Problem = fmt.Sprintf("step %d cannot be answered", step.Step-1)
+		}
+		steps = append(steps, step)
+
+		valid = step.CorrectAnswer != nil
+		input, from, uncertainty = step.exact, step.TargetUoM, step.AnswerUncertainty
+		if valid && roundAfter[step.Step] {
+			input = *step.CorrectAnswer
+		}
+	}
+	return steps
+}
+
+// parseRoundSteps reads the steps whose rounded answer the next step starts
+// from: "all" (the default), "none" or step numbers such as "1;3".
+func parseRoundSteps(s string, stepCount int) (map[int]bool, error) {
+	roundAfter := make(map[int]bool, stepCount)
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "all":
+		for step := 1; step < stepCount; step++ {
+			roundAfter[step] = true
+		}
+		return roundAfter, nil
+	case "none":
+		return roundAfter, nil
+	}
+
+	for _, stepStr := range strings.Split(s, choiceSeparator) {
+		step, err := strconv.Atoi(strings.TrimSpace(stepStr))
+		if err != nil || step < 1 || step >= stepCount {
+			return nil, fmt.Errorf("invalid round steps '%s': give all, none or step numbers from 1 to %d", s, stepCount-1)
+		}
+		roundAfter[step] = true
+	}
+	return roundAfter, nil
+}
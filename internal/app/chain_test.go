@@ -0,0 +1,98 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainedQuestion(t *testing.T) {
+	ws, err := NewWorksheet([][]string{
+		{"ID", "Input", "From Unit", "To Unit", "Points"},
+		{"q1", "2", "cups", "tablespoons > cubic inches", "2"},
+		{"q2", "100", "liters", "gallons", ""},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, ws.Questions, 3)
+
+	first, second := ws.Questions[0], ws.Questions[1]
+	assert.Equal(t, 1, first.Step)
+	assert.Equal(t, "q1.1", first.ID)
+	assert.Equal(t, "tablespoons", first.TargetUoM)
+	assert.Equal(t, 32., *first.CorrectAnswer)
+	assert.Equal(t, 2, second.Step)
+	assert.Equal(t, "q1.2", second.ID)
+	assert.Equal(t, 32., second.Input)
+	assert.Equal(t, "tablespoons", second.InputUoM)
+	assert.Equal(t, "cubic inches", second.TargetUoM)
+	assert.Equal(t, 28.9, *second.CorrectAnswer)
+	assert.Equal(t, 2., second.Points)
+	assert.Equal(t, 0, ws.Questions[2].Step)
+
+	// every step earns its own credit
+	submissions, _ := NewSubmissionList([][]string{{"Doug Doenges", "32", "29", "26.4"}})
+	res := GetResults(ws, submissions)
+	assert.Equal(t, []Decision{Correct, Incorrect, Correct}, res.gradedSubmissions[0].Decisions)
+	assert.Equal(t, 3., res.gradedSubmissions[0].EarnedPoints)
+	assert.Equal(t, 5., res.gradedSubmissions[0].PossiblePoints)
+}
+
+func TestChainedQuestionRoundSteps(t *testing.T) {
+	data := [][]string{
+		{"Input", "From Unit", "To Unit", "Round Steps"},
+		{"1", "liters", "cups > tablespoons", ""},
+	}
+
+	// the rounded 4.2 cups are carried to the next step by default
+	ws, err := NewWorksheet(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 4.2, *ws.Questions[0].CorrectAnswer)
+	assert.Equal(t, 67.2, *ws.Questions[1].CorrectAnswer)
+
+	data[1][3] = "none"
+	ws, err = NewWorksheet(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 67.6, *ws.Questions[1].CorrectAnswer)
+
+	data[1][3] = "1"
+	ws, _ = NewWorksheet(data)
+	assert.Equal(t, 67.2, *ws.Questions[1].CorrectAnswer)
+
+	for _, invalid := range []string{"2", "0", "first"} {
+		data[1][3] = invalid
+		_, err = NewWorksheet(data)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestChainedQuestionErrors(t *testing.T) {
+	// a step that cannot be answered invalidates the rest of the chain
+	ws, err := NewWorksheet([][]string{{"1", "cups", "kelvin > tablespoons"}})
+	assert.NoError(t, err)
+	assert.Len(t, ws.Questions, 2)
+	assert.Nil(t, ws.Questions[0].CorrectAnswer)
+	assert.Nil(t, ws.Questions[1].CorrectAnswer)
+
+	_, err = NewWorksheet([][]string{
+		{"Input", "From Unit", "To Unit", "Type"},
+		{"1", "cups", "pints > quarts", "reverse"},
+	})
+	assert.Error(t, err)
+
+	_, err = NewWorksheet([][]string{
+		{"Input", "From Unit", "To Unit", "Expected Answer"},
+		{"1", "cups", "pints > quarts", "0.5"},
+	})
+	assert.Error(t, err)
+}
+
+func TestLintChainedQuestion(t *testing.T) {
+	diagnostics := LintWorksheet([][]string{
+		{"Input", "From Unit", "To Unit"},
+		{"1", "cups", "pints > litres > quarts"},
+		{"1", "cups", "pints > quarts"},
+	})
+	assert.Equal(t, []Diagnostic{
+		{2, 3, SeverityError, "unknown unit 'litres'"},
+	}, diagnostics)
+}
@@ -0,0 +1,180 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+)
+
+// worksheet column names as they appear in an optional header row
+const (
+	InputColumn           = "input"
+	FromUnitColumn        = "from unit"
+	ToUnitColumn          = "to unit"
+	ToleranceColumn       = "tolerance"
+	PointsColumn          = "points"
+	TypeColumn            = "type"
+	ChoicesColumn         = "choices"
+	IDColumn              = "id"
+	ExpectedAnswerColumn  = "expected answer"
+	RoundStepsColumn      = "round steps"
+	AllowImpossibleColumn = "allow impossible"
+	GroupColumn           = "group"
+	CreditColumn          = "credit"
+	BestColumn            = "best"
+	TextColumn            = "text" // the question as a sentence, see ParseWordProblem
+
+	// question bank tags, ignored when grading
+	DimensionColumn  = "dimension"
+	DifficultyColumn = "difficulty"
+	TopicColumn      = "topic"
+)
+
+// positionalColumns is the column order of a worksheet without a header row,
+// whose rows may instead be a single cell holding the question as a sentence.
+var positionalColumns = []string{InputColumn, FromUnitColumn, ToUnitColumn}
+
+// requiredColumns make a row a header row, unless it has the Text column.
+var requiredColumns = []string{InputColumn, FromUnitColumn, ToUnitColumn}
+
+// columnAliases maps alternate header spellings to their column name.
+var columnAliases = map[string]string{
+	"value":          InputColumn,
+	"from":           FromUnitColumn,
+	"to":             ToUnitColumn,
+	"weight":         PointsColumn,
+	"question id":    IDColumn,
+	"question":       IDColumn,
+	"expected":       ExpectedAnswerColumn,
+	"teacher answer": ExpectedAnswerColumn,
+	"best of":        BestColumn,
+	"word problem":   TextColumn,
+}
+
+// knownColumns holds every column a worksheet header may name.
+var knownColumns = map[string]struct{}{
+	InputColumn:           {},
+	FromUnitColumn:        {},
+	ToUnitColumn:          {},
+	ToleranceColumn:       {},
+	PointsColumn:          {},
+	TypeColumn:            {},
+	ChoicesColumn:         {},
+	IDColumn:              {},
+	ExpectedAnswerColumn:  {},
+	RoundStepsColumn:      {},
+	AllowImpossibleColumn: {},
+	GroupColumn:           {},
+	CreditColumn:          {},
+	BestColumn:            {},
+	TextColumn:            {},
+	DimensionColumn:       {},
+	DifficultyColumn:      {},
+	TopicColumn:           {},
+}
+
+// columnLayout locates worksheet columns within a row.
+type columnLayout struct {
+	indexes    map[string]int
+	positional bool
+}
+
+// columnLabel is how a column is named in a header row this tool writes.
+func columnLabel(column string) string {
+	if column == IDColumn {
+		return "ID"
+	}
+	return metadataLabel(column)
+}
+
+func normalizeColumnName(name string) string {
+	name = strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	if alias, ok := columnAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// isHeaderRow reports whether a row names the required columns or the Text
+// column, so a question row whose cells happen to be column names is not one.
+func isHeaderRow(row []string) bool {
+	names := make(map[string]bool, len(row))
+	for _, cell := range row {
+		names[normalizeColumnName(cell)] = true
+	}
+	if names[TextColumn] {
+		return true
+	}
+	for _, column := range requiredColumns {
+		if !names[column] {
+			return false
+		}
+	}
+	return true
+}
+
+func positionalLayout() columnLayout {
+	indexes := make(map[string]int, len(positionalColumns))
+	for idx, column := range positionalColumns {
+		indexes[column] = idx
+	}
+	return columnLayout{indexes, true}
+}
+
+// detectLayout reads the header row when there is one and returns the layout,
+// the remaining data rows and any header cells that are not known columns.
+func detectLayout(data [][]string) (columnLayout, [][]string, []string, error) {
+	if len(data) == 0 || !isHeaderRow(data[0]) {
+		return positionalLayout(), data, nil, nil
+	}
+
+	indexes := make(map[string]int)
+	unknown := make([]string, 0)
+	for idx, cell := range data[0] {
+		column := normalizeColumnName(cell)
+		if column == "" {
+			continue
+		}
+		if _, ok := knownColumns[column]; !ok {
+			unknown = append(unknown, strings.TrimSpace(cell))
+			continue
+		}
+		if _, ok := indexes[column]; ok {
+			return columnLayout{}, nil, nil, fmt.Errorf("duplicate worksheet column '%s'", strings.TrimSpace(cell))
+		}
+		indexes[column] = idx
+	}
+
+	return columnLayout{indexes, false}, data[1:], unknown, nil
+}
+
+// value returns the trimmed cell of a column, or "" when the row does not have it.
+func (l columnLayout) value(row []string, column string) string {
+	idx, ok := l.indexes[column]
+	if !ok || idx >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[idx])
+}
+
+// position is the 1-based index of a column, or 0 when the layout does not have it.
+func (l columnLayout) position(column string) int {
+	idx, ok := l.indexes[column]
+	if !ok {
+		return 0
+	}
+	return idx + 1
+}
+
+// ColumnError is a problem with one column of a worksheet row.
+type ColumnError struct {
+	Column string
+	Err    error
+}
+
+func (e *ColumnError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ColumnError) Unwrap() error {
+	return e.Err
+}
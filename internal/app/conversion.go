@@ -2,95 +2,128 @@ package app
 
 import (
 	"fmt"
-	"math"
+	"sort"
 	"strings"
 )
 
-type UnitConverter struct {
-	from string
-	to   string
+// scale relates a unit to the base unit of its dimension: base = val*factor + offset.
+type scale struct {
+	factor float64
+	offset float64
 }
 
-type converterFunc func(float64) float64
-
-var unitConversions = map[string]map[UnitConverter]converterFunc{
-	"temperature": {
-		{"kelvin", "rankine"}:    func(val float64) float64 { return val * 1.8 },
-		{"kelvin", "celsius"}:    func(val float64) float64 { return val - 273.15 },
-		{"kelvin", "fahrenheit"}: func(val float64) float64 { return (val-273.15)*9/5 + 32 },
-
-		{"rankine", "kelvin"}:     func(val float64) float64 { return val / 1.8 },
-		{"rankine", "celsius"}:    func(val float64) float64 { return (val - 491.67) * 5 / 9 },
-		{"rankine", "fahrenheit"}: func(val float64) float64 { return val - 459.67 },
+func (s scale) toBase(val float64) float64 {
+	return val*s.factor + s.offset
+}
 
-		{"celsius", "kelvin"}:     func(val float64) float64 { return val + 273.15 },
-		{"celsius", "rankine"}:    func(val float64) float64 { return (val + 273.15) * 1.8 },
-		{"celsius", "fahrenheit"}: func(val float64) float64 { return (val * 9 / 5) + 32 },
+func (s scale) fromBase(val float64) float64 {
+	return (val - s.offset) / s.factor
+}
 
-		{"fahrenheit", "kelvin"}:  func(val float64) float64 { return (val-32)*5/9 + 273.15 },
-		{"fahrenheit", "rankine"}: func(val float64) float64 { return val + 459.67 },
-		{"fahrenheit", "celsius"}: func(val float64) float64 { return (val - 32) * 5 / 9 },
+var unitConversions = map[string]map[string]scale{
+	// temperatures are defined relative to kelvin
+	"temperature": {
+		"kelvin":     {factor: 1},
+		"celsius":    {factor: 1, offset: 273.15},
+		"fahrenheit": {factor: 5.0 / 9, offset: 273.15 - 32*5.0/9},
+		"rankine":    {factor: 5.0 / 9},
+		"reaumur":    {factor: 5.0 / 4, offset: 273.15},
+		"delisle":    {factor: -2.0 / 3, offset: 373.15},
+		"newton":     {factor: 100.0 / 33, offset: 273.15},
+		"romer":      {factor: 40.0 / 21, offset: 273.15 - 7.5*40/21},
 	},
+	// volumes are defined by their exact size in cubic inches, anchored on the
+	// US gallon (231 in³) and the international inch (2.54 cm)
 	"volume": {
-		{"liters", "tablespoons"}:  func(val float64) float64 { return val * 67.628045404 },
-		{"liters", "cubic inches"}: func(val float64) float64 { return val * 61.023744095 },
-		{"liters", "cups"}:         func(val float64) float64 { return val * 4.2267528377 },
-		{"liters", "cubic feet"}:   func(val float64) float64 { return val / 28.316846592 },
-		{"liters", "gallons"}:      func(val float64) float64 { return val / 3.785411784 },
-
-		{"tablespoons", "liters"}:       func(val float64) float64 { return val / 67.628045404 },
-		{"tablespoons", "cubic inches"}: func(val float64) float64 { return val / 1.108225108 },
-		{"tablespoons", "cups"}:         func(val float64) float64 { return val / 16 },
-		{"tablespoons", "cubic feet"}:   func(val float64) float64 { return val / 1915.0129863 },
-		{"tablespoons", "gallons"}:      func(val float64) float64 { return val / 256 },
-
-		{"cubic inches", "liters"}:      func(val float64) float64 { return val / 61.023744095 },
-		{"cubic inches", "tablespoons"}: func(val float64) float64 { return val * 1.108225108 },
-		{"cubic inches", "cups"}:        func(val float64) float64 { return val / 14.4375 },
-		{"cubic inches", "cubic feet"}:  func(val float64) float64 { return val / 1728 },
-		{"cubic inches", "gallons"}:     func(val float64) float64 { return val / 231 },
-
-		{"cups", "liters"}:       func(val float64) float64 { return val / 4.2267528377 },
-		{"cups", "tablespoons"}:  func(val float64) float64 { return val * 16 },
-		{"cups", "cubic inches"}: func(val float64) float64 { return val * 14.4375 },
-		{"cups", "cubic feet"}:   func(val float64) float64 { return val / 119.688311688 },
-		{"cups", "gallons"}:      func(val float64) float64 { return val / 16 },
-
-		{"cubic feet", "liters"}:       func(val float64) float64 { return val * 28.316846592 },
-		{"cubic feet", "tablespoons"}:  func(val float64) float64 { return val * 1915.0129863 },
-		{"cubic feet", "cubic inches"}: func(val float64) float64 { return val * 1728 },
-		{"cubic feet", "cups"}:         func(val float64) float64 { return val * 119.688311688 },
-		{"cubic feet", "gallons"}:      func(val float64) float64 { return val * 7.48051948 },
-
-		{"gallons", "liters"}:       func(val float64) float64 { return val * 3.785411784 },
-		{"gallons", "tablespoons"}:  func(val float64) float64 { return val * 256 },
-		{"gallons", "cubic inches"}: func(val float64) float64 { return val * 231 },
-		{"gallons", "cups"}:         func(val float64) float64 { return val * 16 },
-		{"gallons", "cubic feet"}:   func(val float64) float64 { return val / 7.48051948 },
+		"cubic inches":      {factor: 1},
+		"cubic feet":        {factor: 1728},
+		"cubic yards":       {factor: 46656},
+		"gallons":           {factor: 231},
+		"quarts":            {factor: 231.0 / 4},
+		"pints":             {factor: 231.0 / 8},
+		"cups":              {factor: 231.0 / 16},
+		"fluid ounces":      {factor: 231.0 / 128},
+		"tablespoons":       {factor: 231.0 / 256},
+		"teaspoons":         {factor: 231.0 / 768},
+		"barrels":           {factor: 231 * 42}, // US oil barrel
+		"milliliters":       {factor: 1 / 16.387064},
+		"cubic centimeters": {factor: 1 / 16.387064},
+		"liters":            {factor: 1000 / 16.387064},
+		"cubic meters":      {factor: 1000000 / 16.387064},
+	},
+	// times are defined relative to seconds
+	"time": {
+		"seconds": {factor: 1},
+		"minutes": {factor: 60},
+		"hours":   {factor: 3600},
+		"days":    {factor: 86400},
 	},
 }
 
-var roundFunc = func(value float64) float64 {
-	return math.Floor(value*10+0.5) / 10
+// unitAliases maps alternate spellings to the unit names used in unitConversions.
+var unitAliases = map[string]string{
+	"réaumur": "reaumur",
+	"rømer":   "romer",
+}
+
+// flow rates are every volume unit over every time unit, e.g. "liters per minute"
+func init() {
+	flowRates := make(map[string]scale)
+	for volumeUnit, volumeScale := range unitConversions["volume"] {
+		for timeUnit, timeScale := range unitConversions["time"] {
+			flowRates[volumeUnit+" per "+strings.TrimSuffix(timeUnit, "s")] = scale{factor: volumeScale.factor / timeScale.factor}
+		}
+	}
+	unitConversions["flow rate"] = flowRates
 }
 
+var roundFunc = DefaultRounding.Round
+
 func ConvertUnits(from, to string, val float64) (float64, error) {
+	converted, err := convert(from, to, val)
+	if err != nil {
+		return -1, err
+	}
+	return roundFunc(converted), nil
+}
+
+// convert converts val between two units of the same dimension without rounding.
+func convert(from, to string, val float64) (float64, error) {
 	lowerFrom := strings.ToLower(from)
 	lowerTo := strings.ToLower(to)
 	if lowerFrom == lowerTo {
-		return roundFunc(val), nil
+		return val, nil
 	}
 
-	unitConverter := UnitConverter{
-		lowerFrom,
-		lowerTo,
+	fromDim, fromScale, fromOk := lookupUnit(lowerFrom)
+	toDim, toScale, toOk := lookupUnit(lowerTo)
+	if fromOk && toOk && fromDim == toDim {
+		return toScale.fromBase(fromScale.toBase(val)), nil
 	}
-	if convertFunc, ok := unitConversions["temperature"][unitConverter]; ok {
-		return roundFunc(convertFunc(val)), nil
+
+	return -1, fmt.Errorf("invalid conversion: from %s, to %s", from, to)
+}
+
+// lookupUnit finds the dimension a unit belongs to and its scale within it.
+func lookupUnit(unit string) (string, scale, bool) {
+	unit = strings.ToLower(unit)
+	if alias, ok := unitAliases[unit]; ok {
+		unit = alias
 	}
-	if convertFunc, ok := unitConversions["volume"][unitConverter]; ok {
-		return roundFunc(convertFunc(val)), nil
+	for dimension, scales := range unitConversions {
+		if s, ok := scales[unit]; ok {
+			return dimension, s, true
+		}
 	}
+	return "", scale{}, false
+}
 
-	return -1, fmt.Errorf("invalid conversion: from %s, to %s", from, to)
+// dimensionUnits lists the units of a dimension in alphabetical order.
+func dimensionUnits(dimension string) []string {
+	units := make([]string, 0, len(unitConversions[dimension]))
+	for unit := range unitConversions[dimension] {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+	return units
 }
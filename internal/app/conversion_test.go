@@ -99,6 +99,91 @@ func TestAllConversions(t *testing.T) {
 	}
 }
 
+func TestHistoricalTemperatureConversions(t *testing.T) {
+	// boiling point of water on every scale
+	boiling := map[string]float64{
+		"kelvin":     373.15,
+		"celsius":    100,
+		"fahrenheit": 212,
+		"rankine":    671.67,
+		"reaumur":    80,
+		"delisle":    0,
+		"newton":     33,
+		"romer":      60,
+	}
+
+	for fromUnit, fromVal := range boiling {
+		for toUnit, expected := range boiling {
+			result, err := ConvertUnits(fromUnit, toUnit, fromVal)
+			assert.Nil(t, err)
+			assert.Equal(t, roundFunc(expected), result, []string{fromUnit, toUnit})
+		}
+	}
+
+	result, err := ConvertUnits("Réaumur", "Rømer", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 7.5, result)
+
+	// accented spellings are aliases of the scales, not scales of their own
+	for alias, unit := range unitAliases {
+		assert.NotContains(t, unitConversions["temperature"], alias)
+		assert.Contains(t, unitConversions["temperature"], unit)
+	}
+}
+
+func TestExtendedVolumeConversions(t *testing.T) {
+	conversionsToTest := []struct {
+		From     string
+		To       string
+		Val      float64
+		Expected float64
+	}{
+		{"gallons", "quarts", 1, 4},
+		{"quarts", "pints", 1, 2},
+		{"pints", "cups", 1, 2},
+		{"cups", "fluid ounces", 1, 8},
+		{"fluid ounces", "tablespoons", 1, 2},
+		{"tablespoons", "teaspoons", 1, 3},
+		{"liters", "milliliters", 1, 1000},
+		{"milliliters", "cubic centimeters", 250, 250},
+		{"cubic meters", "liters", 1, 1000},
+		{"cubic yards", "cubic feet", 1, 27},
+		{"barrels", "gallons", 1, 42},
+		{"cubic meters", "cubic yards", 10, 13.1},
+		{"teaspoons", "milliliters", 1, 4.9},
+	}
+
+	for _, c := range conversionsToTest {
+		result, err := ConvertUnits(c.From, c.To, c.Val)
+		assert.Nil(t, err)
+		assert.Equal(t, c.Expected, result, []string{c.From, c.To})
+	}
+}
+
+func TestFlowRateConversions(t *testing.T) {
+	conversionsToTest := []struct {
+		From     string
+		To       string
+		Val      float64
+		Expected float64
+	}{
+		{"hours", "minutes", 2, 120},
+		{"liters per minute", "milliliters per second", 6, 100},
+		{"gallons per hour", "liters per minute", 100, 6.3},
+		{"cubic feet per second", "gallons per hour", 1, 26929.9},
+		{"cubic feet per second", "liters per minute", 1, 1699},
+	}
+
+	for _, c := range conversionsToTest {
+		result, err := ConvertUnits(c.From, c.To, c.Val)
+		assert.Nil(t, err)
+		assert.Equal(t, c.Expected, result, []string{c.From, c.To})
+	}
+
+	_, err := ConvertUnits("liters per minute", "liters", 1)
+	assert.Error(t, err)
+}
+
 func TestInvalidConversion(t *testing.T) {
 	_, err := ConvertUnits("not a unit", "also not a unit", 123.123)
 	assert.Error(t, err)
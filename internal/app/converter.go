@@ -0,0 +1,115 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Converter computes the exact answer for converting val between two units.
+// The worksheet rounds it to produce the answer key.
+type Converter interface {
+	Convert(from, to string, val float64) (float64, error)
+}
+
+// TableConverter is the built-in engine backed by unitConversions.
+type TableConverter struct{}
+
+func (TableConverter) Convert(from, to string, val float64) (float64, error) {
+	return convert(from, to, val)
+}
+
+const DefaultConverterName = "table"
+
+var converters = map[string]Converter{
+	DefaultConverterName: TableConverter{},
+	SIConverterName:      SIConverter{},
+}
+
+// RegisterConverter makes an alternative backend selectable by name.
+func RegisterConverter(name string, c Converter) {
+	converters[strings.ToLower(name)] = c
+}
+
+func GetConverter(name string) (Converter, error) {
+	c, ok := converters[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown converter '%s'. available: %s", name, converterNames())
+	}
+	return c, nil
+}
+
+func converterNames() []string {
+	names := make([]string, 0, len(converters))
+	for name := range converters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Disagreement is a question whose answer differs between two converters.
+// A nil answer means that converter could not convert the question.
+type Disagreement struct {
+	QuestionIdx int
+	Question    Question
+	Primary     *float64
+	Secondary   *float64
+}
+
+func (d Disagreement) String() string {
+	return fmt.Sprintf("question %d (%s %s -> %s): %s vs %s", d.QuestionIdx+1,
+		strconv.FormatFloat(d.Question.Input, 'f', -1, 64), d.Question.InputUoM, d.Question.TargetUoM,
+		formatAnswer(d.Primary), formatAnswer(d.Secondary))
+}
+
+// CrossCheck recomputes every question of the worksheet with other and
+// reports the questions where it disagrees with the worksheet's computed key.
+func CrossCheck(ws Worksheet, other Converter) []Disagreement {
+	disagreements := make([]Disagreement, 0)
+	for idx, q := range ws.Questions {
+		secondary, _, _ := computeKey(q, other, ws.round)
+		if !sameAnswer(q.ComputedAnswer, secondary) {
+			disagreements = append(disagreements, Disagreement{
+				QuestionIdx: idx,
+				Question:    q,
+				Primary:     q.ComputedAnswer,
+				Secondary:   secondary,
+			})
+		}
+	}
+	return disagreements
+}
+
+// DisagreementsToGrid lays out disagreements as a report, labelling the
+// two answers compared.
+func DisagreementsToGrid(disagreements []Disagreement, primaryLabel, secondaryLabel string) [][]string {
+	grid := make([][]string, 0, len(disagreements)+1)
+	grid = append(grid, []string{"Question", "Input", "From Unit", "To Unit", primaryLabel, secondaryLabel})
+	for _, d := range disagreements {
+		grid = append(grid, []string{
+			strconv.Itoa(d.QuestionIdx + 1),
+			strconv.FormatFloat(d.Question.Input, 'f', -1, 64),
+			d.Question.InputUoM,
+			d.Question.TargetUoM,
+			formatAnswer(d.Primary),
+			formatAnswer(d.Secondary),
+		})
+	}
+	return grid
+}
+
+func sameAnswer(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func formatAnswer(answer *float64) string {
+	if answer == nil {
+		return "invalid"
+	}
+	return strconv.FormatFloat(*answer, 'f', -1, 64)
+}
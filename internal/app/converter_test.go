@@ -0,0 +1,72 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeConverter struct {
+	answers map[string]float64
+}
+
+func (f fakeConverter) Convert(from, to string, val float64) (float64, error) {
+	answer, ok := f.answers[from+"->"+to]
+	if !ok {
+		return -1, fmt.Errorf("invalid conversion: from %s, to %s", from, to)
+	}
+	return answer, nil
+}
+
+func TestGetConverter(t *testing.T) {
+	conv, err := GetConverter(DefaultConverterName)
+	assert.NoError(t, err)
+	assert.Equal(t, TableConverter{}, conv)
+
+	_, err = GetConverter("not a backend")
+	assert.Error(t, err)
+
+	RegisterConverter("Fake", fakeConverter{})
+	t.Cleanup(func() { delete(converters, "fake") })
+	_, err = GetConverter("fake")
+	assert.NoError(t, err)
+}
+
+func TestWorksheetParserUsesConverter(t *testing.T) {
+	testData := [][]string{
+		{"100", "liters", "gallons"},
+	}
+	ws, err := WorksheetParser(fakeConverter{map[string]float64{"liters->gallons": 42}})(testData)
+	assert.NoError(t, err)
+	assert.Equal(t, 42., *ws.Questions[0].CorrectAnswer)
+}
+
+func TestCrossCheck(t *testing.T) {
+	testData := [][]string{
+		{"100", "liters", "gallons"},
+		{"100", "kelvin", "celsius"},
+		{"100", "cups", "tablespoons"},
+		{"100", "not a unit", "liters"},
+	}
+	ws, _ := NewWorksheet(testData)
+
+	other := fakeConverter{map[string]float64{
+		"liters->gallons":    26.4,
+		"kelvin->celsius":    -173.2,
+		"not a unit->liters": 1,
+	}}
+	disagreements := CrossCheck(ws, other)
+	assert.Len(t, disagreements, 3)
+
+	assert.Equal(t, 1, disagreements[0].QuestionIdx)
+	assert.Equal(t, -173.1, *disagreements[0].Primary)
+	assert.Equal(t, -173.2, *disagreements[0].Secondary)
+
+	assert.Equal(t, 2, disagreements[1].QuestionIdx)
+	assert.Nil(t, disagreements[1].Secondary)
+	assert.Equal(t, "question 3 (100 cups -> tablespoons): 1600 vs invalid", disagreements[1].String())
+
+	assert.Equal(t, 3, disagreements[2].QuestionIdx)
+	assert.Nil(t, disagreements[2].Primary)
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// domainError is checkDomain unless the question allows impossible quantities.
+func (q Question) domainError() error {
+	if q.AllowImpossible {
+		return nil
+	}
+	return checkDomain(q)
+}
+
+// checkDomain rejects questions about quantities that cannot physically
+// exist: temperatures colder than absolute zero and negative amounts of
+// anything else.
+func checkDomain(q Question) error {
+	unit := q.InputUoM
+	if q.Type == ReverseQuestion {
+		unit = q.TargetUoM
+	}
+	if err := checkQuantity(q.Input, unit); err != nil {
+		return &ColumnError{InputColumn, err}
+	}
+
+	if q.Type == ComparisonQuestion {
+		for _, choice := range q.Choices {
+			quantity, err := parseQuantity(choice)
+			if err != nil {
+				continue
+			}
+			if err := checkQuantity(quantity.Value, quantity.Unit); err != nil {
+				return &ColumnError{ChoicesColumn, err}
+			}
+		}
+	}
+	return nil
+}
+
+// checkQuantity reports why a value of a unit cannot exist. Unknown units are
+// left to the conversion to report.
+func checkQuantity(val float64, unit string) error {
+	dimension, s, ok := lookupUnit(unit)
+	if !ok {
+		return nil
+	}
+
+	valStr := strconv.FormatFloat(val, 'f', -1, 64)
+	if dimension == "temperature" {
+		if s.toBase(val) < -floatSlack(val) {
+			absoluteZero := strconv.FormatFloat(roundTo(s.fromBase(0), 3), 'f', -1, 64)
+			return fmt.Errorf("%s %s is colder than absolute zero (%s %s)", valStr, unit, absoluteZero, unit)
+		}
+		return nil
+	}
+	if val < 0 {
+		return fmt.Errorf("%s %s is a negative %s", valStr, unit, dimension)
+	}
+	return nil
+}
+
+// quantityRange is the range of values of a unit that can exist, the
+// counterpart of checkQuantity. Scales such as Delisle run backwards, so
+// absolute zero is their largest value.
+func quantityRange(unit string) (float64, float64) {
+	dimension, s, _ := lookupUnit(unit)
+	if dimension != "temperature" {
+		return 0, math.Inf(1)
+	}
+	if s.factor < 0 {
+		return math.Inf(-1), s.fromBase(0)
+	}
+	return s.fromBase(0), math.Inf(1)
+}
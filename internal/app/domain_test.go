@@ -0,0 +1,72 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckQuantity(t *testing.T) {
+	assert.NoError(t, checkQuantity(0, "kelvin"))
+	assert.NoError(t, checkQuantity(-273.15, "celsius"))
+	assert.NoError(t, checkQuantity(-459.67, "fahrenheit"))
+	assert.NoError(t, checkQuantity(559.725, "delisle"))
+	assert.NoError(t, checkQuantity(0, "liters"))
+	assert.NoError(t, checkQuantity(-1, "unknown unit"))
+
+	assert.EqualError(t, checkQuantity(-500, "kelvin"), "-500 kelvin is colder than absolute zero (0 kelvin)")
+	assert.EqualError(t, checkQuantity(-500, "fahrenheit"), "-500 fahrenheit is colder than absolute zero (-459.67 fahrenheit)")
+	// Delisle counts down, so colder is larger
+	assert.EqualError(t, checkQuantity(600, "delisle"), "600 delisle is colder than absolute zero (559.725 delisle)")
+	assert.EqualError(t, checkQuantity(-3, "liters"), "-3 liters is a negative volume")
+	assert.EqualError(t, checkQuantity(-1, "minutes"), "-1 minutes is a negative time")
+}
+
+func TestImpossibleQuestions(t *testing.T) {
+	ws, err := NewWorksheet([][]string{
+		{"Input", "From Unit", "To Unit", "Type", "Choices", "Expected Answer", "Allow Impossible"},
+		{"-500", "kelvin", "celsius", "", "", "", ""},
+		{"-3", "liters", "cups", "", "", "", ""},
+		{"-500", "celsius", "kelvin", "reverse", "", "", ""},
+		{"2", "liters", "", "comparison", "-1 gallons;3 cups", "", ""},
+		{"-3", "liters", "cups", "", "", "-12.7", ""},
+		{"-500", "kelvin", "celsius", "", "", "", "yes"},
+		{"-40", "celsius", "fahrenheit", "", "", "", ""},
+	})
+	assert.NoError(t, err)
+
+	for idx, problem := range []string{
+		"-500 kelvin is colder than absolute zero (0 kelvin)",
+		"-3 liters is a negative volume",
+		"-500 kelvin is colder than absolute zero (0 kelvin)",
+		"-1 gallons is a negative volume",
+		"-3 liters is a negative volume",
+	} {
+		assert.Nil(t, ws.Questions[idx].CorrectAnswer, idx)
+		assert.Equal(t, problem, ws.Questions[idx].Problem, idx)
+	}
+
+	// the override keeps intentionally tricky questions
+	assert.True(t, ws.Questions[5].AllowImpossible)
+	assert.Equal(t, -773.1, *ws.Questions[5].CorrectAnswer)
+	assert.Empty(t, ws.Questions[5].Problem)
+	assert.Equal(t, -40., *ws.Questions[6].CorrectAnswer)
+
+	_, err = NewWorksheet([][]string{
+		{"Input", "From Unit", "To Unit", "Allow Impossible"},
+		{"-500", "kelvin", "celsius", "maybe"},
+	})
+	assert.Error(t, err)
+}
+
+func TestLintImpossibleQuestions(t *testing.T) {
+	diagnostics := LintWorksheet([][]string{
+		{"Input", "From Unit", "To Unit", "Type", "Choices", "Allow Impossible"},
+		{"2", "liters", "", "comparison", "-1 gallons;3 cups", ""},
+		{"-500", "kelvin", "celsius", "", "", "yes"},
+	})
+	assert.Equal(t, []Diagnostic{
+		{2, 5, SeverityError, "-1 gallons is a negative volume"},
+		{3, 1, SeverityWarning, "-500 kelvin is colder than absolute zero (0 kelvin), allowed"},
+	}, diagnostics)
+}
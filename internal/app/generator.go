@@ -0,0 +1,152 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultDimensions are the dimensions generated when none are given.
+var DefaultDimensions = []string{"temperature", "volume"}
+
+type GeneratorOptions struct {
+	Dimensions []string // dimensions to draw questions from
+	Units      []string // restricts the units used, all units of the dimensions when empty
+	Count      int
+	Min        float64 // inclusive range of generated inputs
+	Max        float64
+	Decimals   int // decimal places of generated inputs
+	Seed       int64
+}
+
+// GenerateWorksheet produces worksheet rows, header included, that
+// NewWorksheet reads. The same options always produce the same worksheet.
+func GenerateWorksheet(opts GeneratorOptions) ([][]string, error) {
+	if opts.Count <= 0 {
+		return nil, fmt.Errorf("question count must be positive, got %d", opts.Count)
+	}
+	if opts.Min > opts.Max {
+		return nil, fmt.Errorf("invalid value range %s..%s",
+			strconv.FormatFloat(opts.Min, 'f', -1, 64), strconv.FormatFloat(opts.Max, 'f', -1, 64))
+	}
+	if opts.Decimals < 0 {
+		return nil, fmt.Errorf("decimal places must not be negative, got %d", opts.Decimals)
+	}
+
+	unitsByDimension, err := generatorUnits(opts.Dimensions, opts.Units)
+	if err != nil {
+		return nil, err
+	}
+	// questions convert from the units that can take a value in the range,
+	// e.g. not from volumes when every value is negative
+	fromUnits := make(map[string][]string)
+	for dimension, units := range unitsByDimension {
+		for _, unit := range units {
+			if low, high := inputRange(unit, opts); low <= high {
+				fromUnits[dimension] = append(fromUnits[dimension], unit)
+			}
+		}
+	}
+	if len(fromUnits) == 0 {
+		return nil, fmt.Errorf("no unit can take a value from %s to %s",
+			strconv.FormatFloat(opts.Min, 'f', -1, 64), strconv.FormatFloat(opts.Max, 'f', -1, 64))
+	}
+	dimensions := make([]string, 0, len(fromUnits))
+	for dimension := range fromUnits {
+		dimensions = append(dimensions, dimension)
+	}
+	sort.Strings(dimensions)
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	rows := make([][]string, 0, opts.Count+1)
+	rows = append(rows, []string{"Input", "From Unit", "To Unit"})
+	for range opts.Count {
+		row, ok := []string(nil), false
+		for attempt := 0; attempt < maxGeneratorAttempts && !ok; attempt++ {
+			row, ok = drawQuestion(rng, opts, dimensions, fromUnits, unitsByDimension)
+		}
+		if !ok {
+			return nil, fmt.Errorf("no question with an answer that rounds to more than 0 found from these options")
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// maxGeneratorAttempts is how many questions are drawn before giving up on
+// finding one with an answer that does not round to 0.
+const maxGeneratorAttempts = 100
+
+// drawQuestion draws a question row, reporting whether it is worth asking:
+// a key that rounds to 0 when the answer is not 0, such as 30 cubic
+// centimeters in barrels, would accept almost any small response.
+func drawQuestion(rng *rand.Rand, opts GeneratorOptions, dimensions []string, fromUnits, unitsByDimension map[string][]string) ([]string, bool) {
+	dimension := dimensions[rng.Intn(len(dimensions))]
+	from := fromUnits[dimension][rng.Intn(len(fromUnits[dimension]))]
+	to := from
+	for to == from {
+		to = unitsByDimension[dimension][rng.Intn(len(unitsByDimension[dimension]))]
+	}
+	low, high := inputRange(from, opts)
+	val := roundTo(low+rng.Float64()*(high-low), opts.Decimals)
+
+	exact, err := convert(from, to, val)
+	row := []string{strconv.FormatFloat(val, 'f', opts.Decimals, 64), from, to}
+	return row, err == nil && (exact == 0 || DefaultRounding.Round(exact) != 0)
+}
+
+// inputRange is the part of the options' range a unit can take, on the grid
+// of the options' decimal places. It is empty when low > high.
+func inputRange(unit string, opts GeneratorOptions) (float64, float64) {
+	low, high := quantityRange(unit)
+	pow := math.Pow(10, float64(opts.Decimals))
+	low = math.Ceil(math.Max(low, opts.Min)*pow-floatSlack(opts.Min*pow)) / pow
+	high = math.Floor(math.Min(high, opts.Max)*pow+floatSlack(opts.Max*pow)) / pow
+	return low, high
+}
+
+// generatorUnits groups the units questions may use by dimension, keeping
+// only dimensions with at least two units to convert between.
+func generatorUnits(dimensions, units []string) (map[string][]string, error) {
+	if len(dimensions) == 0 {
+		dimensions = DefaultDimensions
+	}
+
+	allowed := make(map[string]struct{}, len(units))
+	for _, unit := range units {
+		unit = strings.TrimSpace(strings.ToLower(unit))
+		if _, _, ok := lookupUnit(unit); !ok {
+			return nil, fmt.Errorf("unknown unit '%s'", unit)
+		}
+		if alias, ok := unitAliases[unit]; ok {
+			unit = alias
+		}
+		allowed[unit] = struct{}{}
+	}
+
+	unitsByDimension := make(map[string][]string)
+	for _, dimension := range dimensions {
+		dimension = strings.TrimSpace(strings.ToLower(dimension))
+		if _, ok := unitConversions[dimension]; !ok {
+			return nil, fmt.Errorf("unknown dimension '%s'", dimension)
+		}
+		selected := make([]string, 0)
+		for _, unit := range dimensionUnits(dimension) {
+			if _, ok := allowed[unit]; ok || len(allowed) == 0 {
+				selected = append(selected, unit)
+			}
+		}
+		if len(selected) >= 2 {
+			unitsByDimension[dimension] = selected
+		}
+	}
+
+	if len(unitsByDimension) == 0 {
+		return nil, fmt.Errorf("at least two units of the same dimension are needed to generate questions")
+	}
+	return unitsByDimension, nil
+}
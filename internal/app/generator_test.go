@@ -0,0 +1,94 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateWorksheet(t *testing.T) {
+	opts := GeneratorOptions{
+		Dimensions: []string{"temperature", "volume"},
+		Units:      []string{"kelvin", "Celsius", "liters", "cups", "Rømer"},
+		Count:      20,
+		Min:        10,
+		Max:        20,
+		Decimals:   1,
+		Seed:       42,
+	}
+
+	rows, err := GenerateWorksheet(opts)
+	assert.NoError(t, err)
+	assert.Len(t, rows, opts.Count+1)
+
+	ws, err := NewWorksheet(rows)
+	assert.NoError(t, err)
+	assert.Len(t, ws.Questions, opts.Count)
+	for _, q := range ws.Questions {
+		assert.NotNil(t, q.CorrectAnswer)
+		assert.NotEqual(t, q.InputUoM, q.TargetUoM)
+		assert.Contains(t, []string{"kelvin", "celsius", "romer", "liters", "cups"}, q.InputUoM)
+		assert.GreaterOrEqual(t, q.Input, opts.Min)
+		assert.LessOrEqual(t, q.Input, opts.Max)
+		assert.Equal(t, roundTo(q.Input, 1), q.Input)
+	}
+
+	// the same seed reproduces the worksheet
+	again, _ := GenerateWorksheet(opts)
+	assert.Equal(t, rows, again)
+
+	opts.Seed = 7
+	different, _ := GenerateWorksheet(opts)
+	assert.NotEqual(t, rows, different)
+}
+
+func TestGenerateWorksheetValidInputs(t *testing.T) {
+	// negative values only suit temperatures above absolute zero
+	opts := GeneratorOptions{Count: 50, Min: -500, Max: 10, Seed: 3}
+	rows, err := GenerateWorksheet(opts)
+	assert.NoError(t, err)
+	ws, err := NewWorksheet(rows)
+	assert.NoError(t, err)
+	for _, q := range ws.Questions {
+		assert.NotNil(t, q.CorrectAnswer, q.Problem)
+		if dimension, _, _ := lookupUnit(q.InputUoM); q.Input < 0 {
+			assert.Equal(t, "temperature", dimension)
+		}
+	}
+
+	// small volumes are not asked in units whose answer rounds to 0
+	opts = GeneratorOptions{Count: 50, Dimensions: []string{"volume"}, Min: 1, Max: 50, Seed: 5}
+	rows, err = GenerateWorksheet(opts)
+	assert.NoError(t, err)
+	ws, err = NewWorksheet(rows)
+	assert.NoError(t, err)
+	for _, q := range ws.Questions {
+		assert.NotEqual(t, 0., *q.CorrectAnswer, q.ToGrid())
+	}
+	opts.Units, opts.Min, opts.Max, opts.Decimals = []string{"teaspoons", "milliliters"}, 0.01, 0.01, 2
+	_, err = GenerateWorksheet(opts)
+	assert.EqualError(t, err, "no question with an answer that rounds to more than 0 found from these options")
+
+	opts = GeneratorOptions{Count: 50, Dimensions: []string{"volume"}, Min: -500, Max: -1}
+	_, err = GenerateWorksheet(opts)
+	assert.EqualError(t, err, "no unit can take a value from -500 to -1")
+}
+
+func TestGenerateWorksheetInvalidOptions(t *testing.T) {
+	valid := GeneratorOptions{Count: 5, Min: 0, Max: 100}
+	_, err := GenerateWorksheet(valid)
+	assert.NoError(t, err)
+
+	invalid := []GeneratorOptions{
+		{Count: 0, Max: 100},
+		{Count: 5, Min: 10, Max: 1},
+		{Count: 5, Max: 100, Decimals: -1},
+		{Count: 5, Max: 100, Dimensions: []string{"mass"}},
+		{Count: 5, Max: 100, Units: []string{"furlongs"}},
+		{Count: 5, Max: 100, Units: []string{"kelvin", "liters"}},
+	}
+	for _, opts := range invalid {
+		_, err := GenerateWorksheet(opts)
+		assert.Error(t, err, opts)
+	}
+}
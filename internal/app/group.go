@@ -0,0 +1,146 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Credit string
+
+const (
+	Required Credit = "required"
+	// only scored when answered
+	Optional Credit = "optional"
+	// earned points count, possible points do not
+	ExtraCredit Credit = "extra credit"
+)
+
+var creditAliases = map[string]Credit{
+	"":             Required,
+	"required":     Required,
+	"optional":     Optional,
+	"extra credit": ExtraCredit,
+	"extra":        ExtraCredit,
+	"bonus":        ExtraCredit,
+}
+
+func parseCredit(s string) (Credit, error) {
+	credit, ok := creditAliases[strings.Join(strings.Fields(strings.ToLower(s)), " ")]
+	if !ok {
+		return "", fmt.Errorf("unknown credit '%s': use required, optional or extra credit", s)
+	}
+	return credit, nil
+}
+
+// Group is a named set of worksheet questions scored together, "" for questions without one.
+type Group struct {
+	Name string
+	Best int // only the best Best questions count, 0 to count them all
+}
+
+// addGroup adds a question's group to the worksheet's groups in order
+// of appearance. Questions of a group must agree on its best count.
+func addGroup(groups []Group, name string, best int) ([]Group, error) {
+	for idx := range groups {
+		if !strings.EqualFold(groups[idx].Name, name) {
+			continue
+		}
+		switch {
+		case best == 0 || best == groups[idx].Best:
+		case groups[idx].Best == 0:
+			groups[idx].Best = best
+		default:
+			return groups, fmt.Errorf("group '%s' counts the best %d and the best %d questions", name, groups[idx].Best, best)
+		}
+		return groups, nil
+	}
+	return append(groups, Group{name, best}), nil
+}
+
+func parseBest(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	best, err := strconv.Atoi(s)
+	if err != nil || best < 1 {
+		return 0, fmt.Errorf("invalid best '%s': give the number of questions that count", s)
+	}
+	return best, nil
+}
+
+// HasGroups reports whether any question belongs to a named group.
+func (ws Worksheet) HasGroups() bool {
+	for _, group := range ws.Groups {
+		if group.Name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupScore is a student's subtotal for one group.
+type GroupScore struct {
+	Name           string
+	EarnedPoints   float64
+	PossiblePoints float64
+}
+
+// tallyGroups scores each group of the worksheet and totals them.
+func (s *Submission) tallyGroups(ws Worksheet) {
+	type score struct{ earned, possible float64 }
+
+	s.EarnedPoints, s.PossiblePoints = 0, 0
+	s.Groups = make([]GroupScore, 0, len(ws.Groups))
+	for _, group := range ws.Groups {
+		subtotal := GroupScore{Name: group.Name}
+		counted := make([]score, 0)
+		for idx, q := range ws.Questions {
+			if !strings.EqualFold(q.Group, group.Name) || s.Decisions[idx] == Invalid {
+				continue
+			}
+			earned := 0.
+			if s.Decisions[idx] == Correct {
+				earned = q.Points
+			}
+			switch {
+			case q.Credit == ExtraCredit:
+				subtotal.EarnedPoints += earned
+			case q.Credit == Optional && !s.answered(idx):
+			default:
+				counted = append(counted, score{earned, q.Points})
+			}
+		}
+
+		if group.Best > 0 && len(counted) > group.Best {
+			sort.SliceStable(counted, func(i, j int) bool {
+				if counted[i].earned != counted[j].earned {
+					return counted[i].earned > counted[j].earned
+				}
+				return counted[i].possible < counted[j].possible
+			})
+			counted = counted[:group.Best]
+		}
+		for _, c := range counted {
+			subtotal.EarnedPoints += c.earned
+			subtotal.PossiblePoints += c.possible
+		}
+
+		s.Groups = append(s.Groups, subtotal)
+		s.EarnedPoints += subtotal.EarnedPoints
+		s.PossiblePoints += subtotal.PossiblePoints
+	}
+}
+
+func (s *Submission) answered(idx int) bool {
+	return idx < len(s.Responses) && s.Responses[idx] != nil
+}
+
+// groupLabel names a group's score row.
+func groupLabel(name string) string {
+	if name == "" {
+		return "Other Score"
+	}
+	return name + " Score"
+}
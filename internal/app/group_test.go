@@ -0,0 +1,94 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func groupedWorksheet(t *testing.T) Worksheet {
+	ws, err := NewWorksheet([][]string{
+		{"Input", "From Unit", "To Unit", "Group", "Credit", "Best", "Points"},
+		{"100", "fahrenheit", "celsius", "Temperature", "", "", ""},
+		{"0", "celsius", "kelvin", "Temperature", "", "", ""},
+		{"212", "fahrenheit", "kelvin", "temperature", "extra credit", "", "2"},
+		{"1", "gallons", "quarts", "Volume", "", "2", ""},
+		{"1", "quarts", "cups", "Volume", "", "", ""},
+		{"1", "cups", "tablespoons", "Volume", "", "", ""},
+		{"100", "liters", "gallons", "", "optional", "", ""},
+	})
+	assert.NoError(t, err)
+	return ws
+}
+
+func TestParseCredit(t *testing.T) {
+	for s, expected := range map[string]Credit{"": Required, "Optional": Optional, " Extra  Credit ": ExtraCredit, "bonus": ExtraCredit} {
+		credit, err := parseCredit(s)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, credit)
+	}
+	_, err := parseCredit("mandatory")
+	assert.Error(t, err)
+}
+
+func TestWorksheetGroups(t *testing.T) {
+	ws := groupedWorksheet(t)
+	assert.True(t, ws.HasGroups())
+	assert.Equal(t, []Group{{"Temperature", 0}, {"Volume", 2}, {"", 0}}, ws.Groups)
+	assert.Equal(t, ExtraCredit, ws.Questions[2].Credit)
+	assert.Equal(t, "Volume", ws.Questions[3].Group)
+
+	plain, _ := NewWorksheet([][]string{{"100", "liters", "gallons"}})
+	assert.False(t, plain.HasGroups())
+
+	_, err := NewWorksheet([][]string{
+		{"Input", "From Unit", "To Unit", "Group", "Best"},
+		{"1", "gallons", "quarts", "Volume", "1"},
+		{"1", "quarts", "cups", "Volume", "2"},
+	})
+	assert.Error(t, err)
+	_, err = NewWorksheet([][]string{
+		{"Input", "From Unit", "To Unit", "Best"},
+		{"1", "gallons", "quarts", "none"},
+	})
+	assert.Error(t, err)
+}
+
+func TestGradeGroups(t *testing.T) {
+	ws := groupedWorksheet(t)
+	submissions, _ := NewSubmissionList([][]string{
+		// extra credit earned, best 2 of 3 volume questions, optional skipped
+		{"Student A", "37.8", "0", "373.2", "4", "1", "16", ""},
+		// extra credit missed, one volume question wrong, optional wrong
+		{"Student B", "37.8", "273.2", "0", "4", "0", "16", "1"},
+	})
+	res := GetResults(ws, submissions)
+
+	a, b := res.gradedSubmissions[0], res.gradedSubmissions[1]
+	assert.Equal(t, []GroupScore{{"Temperature", 3, 2}, {"Volume", 2, 2}, {"", 0, 0}}, a.Groups)
+	assert.Equal(t, 5., a.EarnedPoints)
+	assert.Equal(t, 4., a.PossiblePoints)
+	assert.Equal(t, []GroupScore{{"Temperature", 2, 2}, {"Volume", 2, 2}, {"", 0, 1}}, b.Groups)
+	assert.Equal(t, 4., b.EarnedPoints)
+	assert.Equal(t, 5., b.PossiblePoints)
+
+	gridDisplay := res.ToGridDisplay()
+	rows := len(gridDisplay)
+	assert.Equal(t, []string{"", "", "", "Temperature Score", "", "3/2", "150%", "2/2", "100%"}, gridDisplay[rows-4])
+	assert.Equal(t, []string{"", "", "", "Volume Score", "", "2/2", "100%", "2/2", "100%"}, gridDisplay[rows-3])
+	assert.Equal(t, []string{"", "", "", "Other Score", "", "0/0", "0%", "0/1", "0%"}, gridDisplay[rows-2])
+	assert.Equal(t, []string{"", "", "", "Score", "", "5/4", "125%", "4/5", "80%"}, gridDisplay[rows-1])
+}
+
+func TestLintGroups(t *testing.T) {
+	diagnostics := LintWorksheet([][]string{
+		{"Input", "From Unit", "To Unit", "Group", "Best", "Credit"},
+		{"1", "gallons", "quarts", "Volume", "1", ""},
+		{"1", "quarts", "cups", "Volume", "2", ""},
+		{"1", "cups", "pints", "Volume", "", "sometimes"},
+	})
+	assert.Equal(t, []Diagnostic{
+		{3, 5, SeverityError, "group 'Volume' counts the best 1 and the best 2 questions"},
+		{4, 6, SeverityError, "unknown credit 'sometimes': use required, optional or extra credit in question: 1,cups,pints,Volume,,sometimes"},
+	}, diagnostics)
+}
@@ -0,0 +1,206 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a problem found in a worksheet file. Row and Column count
+// from 1 as in a spreadsheet; Column is 0 when the whole row is concerned.
+type Diagnostic struct {
+	Row      int
+	Column   int
+	Severity Severity
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s", d.Row, d.Column, d.Severity, d.Message)
+}
+
+// suspiciousMagnitude is the input size above which a value is likely a typo.
+const suspiciousMagnitude = 1e9
+
+// LintWorksheet checks every row of a worksheet file and reports all the
+// problems found, instead of stopping at the first one like NewWorksheet.
+// Answers are computed with the worksheet's own rounding, as when grading.
+func LintWorksheet(data [][]string) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+
+	ws := Worksheet{}
+	metadataRows := 0
+	for ; metadataRows < len(data); metadataRows++ {
+		if isBlankRow(data[metadataRows]) {
+			continue
+		}
+		key, value, ok := splitMetadataRow(data[metadataRows])
+		if !ok {
+			break
+		}
+		if err := ws.Metadata.set(key, value); err != nil {
+			diagnostics = append(diagnostics, Diagnostic{metadataRows + 1, 2, SeverityError, err.Error()})
+		}
+	}
+
+	layout, rows, unknown, err := detectLayout(data[metadataRows:])
+	if err != nil {
+		return append(diagnostics, Diagnostic{metadataRows + 1, 0, SeverityError, err.Error()})
+	}
+	firstRow := metadataRows + 1
+	if !layout.positional {
+		for _, column := range unknown {
+			diagnostics = append(diagnostics, Diagnostic{firstRow, 0, SeverityWarning,
+				fmt.Sprintf("unknown column '%s' is ignored", column)})
+		}
+		firstRow++
+	}
+
+	seen := make(map[string]int)
+	seenIDs := make(map[string]int)
+	groups := make([]Group, 0)
+	for idx, row := range rows {
+		rowNum := firstRow + idx
+		if layout.positional && len(row) != QuestionLength && len(row) != 1 {
+			diagnostics = append(diagnostics, Diagnostic{rowNum, 0, SeverityError,
+				fmt.Sprintf("wrong column count: expected %d, got %d", QuestionLength, len(row))})
+			continue
+		}
+
+		questions, err := buildQuestions(row, layout, TableConverter{}, ws.round)
+		if err != nil {
+			var columnErr *ColumnError
+			column := 0
+			if errors.As(err, &columnErr) {
+				column = layout.position(columnErr.Column)
+			}
+			diagnostics = append(diagnostics, Diagnostic{rowNum, column, SeverityError, err.Error()})
+			continue
+		}
+
+		groups, err = addGroup(groups, questions[0].Group, questions[0].groupBest)
+		if err != nil {
+			diagnostics = append(diagnostics, Diagnostic{rowNum, layout.position(BestColumn), SeverityError, err.Error()})
+		}
+
+		// later steps of a chain cannot be answered once one step cannot
+		invalid := false
+		for _, q := range questions {
+			if q.CorrectAnswer == nil && !invalid {
+				diagnostics = append(diagnostics, lintInvalidQuestion(q, layout, rowNum, ws.round))
+				invalid = true
+			}
+			diagnostics = append(diagnostics, lintSuspiciousValues(q, layout, rowNum)...)
+
+			if q.ID != "" {
+				if firstSeen, ok := seenIDs[strings.ToLower(q.ID)]; ok {
+					diagnostics = append(diagnostics, Diagnostic{rowNum, layout.position(IDColumn), SeverityError,
+						fmt.Sprintf("question ID '%s' is already used in row %d", q.ID, firstSeen)})
+				} else {
+					seenIDs[strings.ToLower(q.ID)] = rowNum
+				}
+			}
+		}
+
+		identity := rowIdentity(questions)
+		if firstSeen, ok := seen[identity]; ok {
+			diagnostics = append(diagnostics, Diagnostic{rowNum, 0, SeverityWarning,
+				fmt.Sprintf("duplicate of the question in row %d", firstSeen)})
+		} else {
+			seen[identity] = rowNum
+		}
+	}
+
+	return diagnostics
+}
+
+// lintInvalidQuestion explains why a question has no answer.
+func lintInvalidQuestion(q Question, layout columnLayout, rowNum int, round func(float64) float64) Diagnostic {
+	fromDim, _, fromOk := lookupUnit(q.InputUoM)
+	if !fromOk {
+		return Diagnostic{rowNum, layout.position(FromUnitColumn), SeverityError, unknownUnitMessage(q.InputUoM)}
+	}
+	// comparison and choose unit questions have no unit to convert to
+	if q.Type != ComparisonQuestion && q.Type != ChooseUnitQuestion {
+		toDim, _, toOk := lookupUnit(q.TargetUoM)
+		if !toOk {
+			return Diagnostic{rowNum, layout.position(ToUnitColumn), SeverityError, unknownUnitMessage(q.TargetUoM)}
+		}
+		if fromDim != toDim {
+			return Diagnostic{rowNum, layout.position(ToUnitColumn), SeverityError,
+				fmt.Sprintf("incompatible dimensions: %s is a %s unit, %s is a %s unit", q.InputUoM, fromDim, q.TargetUoM, toDim)}
+		}
+	}
+
+	if err := q.domainError(); err != nil {
+		var columnErr *ColumnError
+		errors.As(err, &columnErr)
+		return Diagnostic{rowNum, layout.position(columnErr.Column), SeverityError, err.Error()}
+	}
+
+	_, err := answerQuestion(q, TableConverter{}, round)
+	message := "question cannot be answered"
+	if err != nil {
+		message = err.Error()
+	}
+	return Diagnostic{rowNum, layout.position(ChoicesColumn), SeverityError, message}
+}
+
+func unknownUnitMessage(unit string) string {
+	if unit == "" {
+		return "missing unit"
+	}
+	return fmt.Sprintf("unknown unit '%s'", unit)
+}
+
+// lintSuspiciousValues warns about inputs that are valid but likely mistakes.
+func lintSuspiciousValues(q Question, layout columnLayout, rowNum int) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+	inputColumn := layout.position(InputColumn)
+
+	if q.InputUoM == q.TargetUoM && q.Type != ComparisonQuestion {
+		diagnostics = append(diagnostics, Diagnostic{rowNum, layout.position(ToUnitColumn), SeverityWarning,
+			fmt.Sprintf("converts %s to itself", q.InputUoM)})
+	}
+	if math.Abs(q.Input) >= suspiciousMagnitude {
+		diagnostics = append(diagnostics, Diagnostic{rowNum, inputColumn, SeverityWarning,
+			fmt.Sprintf("input %s is unusually large", strconv.FormatFloat(q.Input, 'f', -1, 64))})
+	}
+
+	// impossible quantities are errors unless the question allows them
+	if q.AllowImpossible {
+		if err := checkDomain(q); err != nil {
+			var columnErr *ColumnError
+			errors.As(err, &columnErr)
+			diagnostics = append(diagnostics, Diagnostic{rowNum, layout.position(columnErr.Column), SeverityWarning,
+				err.Error() + ", allowed"})
+		}
+	}
+
+	return diagnostics
+}
+
+// rowIdentity is equal for worksheet rows that ask the same thing, comparing
+// every step of chained questions.
+func rowIdentity(questions []Question) string {
+	identities := make([]string, 0, len(questions))
+	for _, q := range questions {
+		identities = append(identities, questionIdentity(q))
+	}
+	return strings.Join(identities, chainSeparator)
+}
+
+// questionIdentity is equal for questions that ask the same thing.
+func questionIdentity(q Question) string {
+	return strings.Join([]string{string(q.Type), strconv.FormatFloat(q.Input, 'f', -1, 64),
+		q.InputUoM, q.TargetUoM, strings.ToLower(strings.Join(q.Choices, choiceSeparator))}, "|")
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintValidWorksheet(t *testing.T) {
+	diagnostics := LintWorksheet([][]string{
+		{"100", "Fahrenheit", "Celsius"},
+		{"100", "Cups", "cubic inches"},
+	})
+	assert.Empty(t, diagnostics)
+}
+
+func TestLintWorksheet(t *testing.T) {
+	testData := [][]string{
+		{"Title", "Quiz"},
+		{"Rounding", "lots"},
+		{"Input", "From Unit", "To Unit", "Points", "Notes"},
+		{"abc", "liters", "gallons", "", ""},
+		{"100", "litres", "gallons", "", ""},
+		{"100", "liters", "kelvin", "", ""},
+		{"100", "liters", "gallons", "-2", ""},
+		{"100", "liters", "gallons", "", ""},
+		{"100", "Liters", "Gallons", "", ""},
+		{"-500", "kelvin", "celsius", "", ""},
+		{"-3", "liters", "liters", "", ""},
+		{"1e12", "cups", "liters", "", ""},
+	}
+
+	expected := []Diagnostic{
+		{2, 2, SeverityError, "invalid rounding 'lots': give decimal places (2dp), significant figures (3sf) or auto"},
+		{3, 0, SeverityWarning, "unknown column 'Notes' is ignored"},
+		{4, 1, SeverityError, "invalid input number(s) given: abc,liters,gallons,,"},
+		{5, 2, SeverityError, "unknown unit 'litres'"},
+		{6, 3, SeverityError, "incompatible dimensions: liters is a volume unit, kelvin is a temperature unit"},
+		{7, 4, SeverityError, "invalid points '-2' in question: 100,liters,gallons,-2,"},
+		{9, 0, SeverityWarning, "duplicate of the question in row 8"},
+		{10, 1, SeverityError, "-500 kelvin is colder than absolute zero (0 kelvin)"},
+		{11, 1, SeverityError, "-3 liters is a negative volume"},
+		{11, 3, SeverityWarning, "converts liters to itself"},
+		{12, 1, SeverityWarning, "input 1000000000000 is unusually large"},
+	}
+	assert.Equal(t, expected, LintWorksheet(testData))
+}
+
+func TestLintPositionalWorksheet(t *testing.T) {
+	diagnostics := LintWorksheet([][]string{
+		{"too", "many", "fields", "in this worksheet"},
+		{"100", "", "celsius"},
+	})
+	assert.Equal(t, []Diagnostic{
+		{1, 0, SeverityError, "wrong column count: expected 3, got 4"},
+		{2, 2, SeverityError, "missing unit"},
+	}, diagnostics)
+	assert.Equal(t, "1:0: error: wrong column count: expected 3, got 4", diagnostics[0].String())
+
+	diagnostics = LintWorksheet([][]string{{"Input", "From Unit"}})
+	assert.Len(t, diagnostics, 1)
+	assert.Equal(t, 1, diagnostics[0].Row)
+}
+
+func TestLintDuplicateQuestionIDs(t *testing.T) {
+	diagnostics := LintWorksheet([][]string{
+		{"ID", "Input", "From Unit", "To Unit"},
+		{"q1", "100", "liters", "gallons"},
+		{"Q1", "100", "kelvin", "celsius"},
+	})
+	assert.Equal(t, []Diagnostic{
+		{3, 1, SeverityError, "question ID 'Q1' is already used in row 2"},
+	}, diagnostics)
+}
+
+func TestLintWorksheetRounding(t *testing.T) {
+	testData := [][]string{
+		{"Input", "From Unit", "To Unit", "Type", "Choices"},
+		{"100", "liters", "gallons", "multiple choice", "26.4;28"},
+	}
+	assert.Empty(t, LintWorksheet(testData))
+
+	// the answer key is checked at the worksheet's own rounding, as when grading
+	assert.Equal(t, []Diagnostic{{3, 5, SeverityError, "none of the choices is 26.42"}},
+		LintWorksheet(append([][]string{{"Rounding", "2dp"}}, testData...)))
+}
@@ -0,0 +1,193 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Metadata describes a worksheet. It is given as "Key, value" (or "Key: value")
+// rows above the questions, or on a separate sheet of an XLSX workbook.
+type Metadata struct {
+	Title   string
+	Course  string
+	Section string
+	DueDate string
+	Author  string
+
+	Rounding  *Rounding  // rounding of the answer key and responses, nil for the default
+	Tolerance *Tolerance // default tolerance of the worksheet's questions
+
+	SignificantFigures bool // grade the significant figures of responses too
+	AcceptUncertainty  bool // accept responses within an answer's propagated uncertainty
+}
+
+// metadata keys in the order they are displayed
+const (
+	TitleKey       = "title"
+	CourseKey      = "course"
+	SectionKey     = "section"
+	DueDateKey     = "due date"
+	AuthorKey      = "author"
+	RoundingKey    = "rounding"
+	ToleranceKey   = "tolerance"
+	SigFigsKey     = "significant figures"
+	UncertaintyKey = "accept uncertainty"
+)
+
+var metadataKeys = []string{TitleKey, CourseKey, SectionKey, DueDateKey, AuthorKey, RoundingKey, ToleranceKey, SigFigsKey, UncertaintyKey}
+
+// splitMetadataRow returns the key and value of a metadata row.
+func splitMetadataRow(row []string) (string, string, bool) {
+	cells := make([]string, 0, 2)
+	for _, cell := range row {
+		if cell = strings.TrimSpace(cell); cell != "" {
+			cells = append(cells, cell)
+		}
+	}
+
+	var key, value string
+	switch len(cells) {
+	case 1:
+		var found bool
+		key, value, found = strings.Cut(cells[0], ":")
+		if !found {
+			return "", "", false
+		}
+	case 2:
+		key, value = cells[0], cells[1]
+	default:
+		return "", "", false
+	}
+
+	key = strings.Join(strings.Fields(strings.ToLower(strings.TrimSuffix(strings.TrimSpace(key), ":"))), " ")
+	for _, known := range metadataKeys {
+		if key == known {
+			return key, strings.TrimSpace(value), true
+		}
+	}
+	return "", "", false
+}
+
+// parseMetadata reads the metadata rows at the top of the data and returns
+// the rows that follow them. Blank rows around the metadata, such as the
+// spacer the results are written with, are skipped.
+func parseMetadata(data [][]string) (Metadata, [][]string, error) {
+	metadata := Metadata{}
+	idx := 0
+	for ; idx < len(data); idx++ {
+		if isBlankRow(data[idx]) {
+			continue
+		}
+		key, value, ok := splitMetadataRow(data[idx])
+		if !ok {
+			break
+		}
+		if err := metadata.set(key, value); err != nil {
+			return Metadata{}, nil, err
+		}
+	}
+	return metadata, data[idx:], nil
+}
+
+// isBlankRow reports whether every cell of a row is empty.
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Metadata) set(key, value string) error {
+	switch key {
+	case TitleKey:
+		m.Title = value
+	case CourseKey:
+		m.Course = value
+	case SectionKey:
+		m.Section = value
+	case DueDateKey:
+		m.DueDate = value
+	case AuthorKey:
+		m.Author = value
+	case RoundingKey:
+		rounding, err := ParseRounding(value)
+		if err != nil {
+			return err
+		}
+		m.Rounding = &rounding
+	case ToleranceKey:
+		tolerance, err := ParseTolerance(value)
+		if err != nil {
+			return err
+		}
+		m.Tolerance = &tolerance
+	case SigFigsKey:
+		on, err := parseSwitch(value)
+		if err != nil {
+			return fmt.Errorf("invalid significant figures '%s': give yes or no", value)
+		}
+		m.SignificantFigures = on
+	case UncertaintyKey:
+		on, err := parseSwitch(value)
+		if err != nil {
+			return fmt.Errorf("invalid accept uncertainty '%s': give yes or no", value)
+		}
+		m.AcceptUncertainty = on
+	}
+	return nil
+}
+
+// parseSwitch reads yes/no, on/off or true/false.
+func parseSwitch(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "yes", "y", "on":
+		return true, nil
+	case "no", "n", "off":
+		return false, nil
+	}
+	return strconv.ParseBool(strings.TrimSpace(value))
+}
+
+// ToGrid lists the metadata that is set as "Key, value" rows.
+func (m Metadata) ToGrid() [][]string {
+	values := map[string]string{
+		TitleKey:   m.Title,
+		CourseKey:  m.Course,
+		SectionKey: m.Section,
+		DueDateKey: m.DueDate,
+		AuthorKey:  m.Author,
+	}
+	if m.Rounding != nil {
+		values[RoundingKey] = m.Rounding.String()
+	}
+	if m.Tolerance != nil {
+		values[ToleranceKey] = m.Tolerance.String()
+	}
+	if m.SignificantFigures {
+		values[SigFigsKey] = "yes"
+	}
+	if m.AcceptUncertainty {
+		values[UncertaintyKey] = "yes"
+	}
+
+	grid := make([][]string, 0, len(metadataKeys))
+	for _, key := range metadataKeys {
+		if values[key] == "" {
+			continue
+		}
+		grid = append(grid, []string{metadataLabel(key), values[key]})
+	}
+	return grid
+}
+
+// metadataLabel capitalizes a key for display, e.g. "due date" as "Due Date".
+func metadataLabel(key string) string {
+	words := strings.Fields(key)
+	for idx, word := range words {
+		words[idx] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorksheetMetadata(t *testing.T) {
+	testData := [][]string{
+		{"Title", "Unit Quiz", ""},
+		{"Course: Chemistry 101"},
+		{"section:", "B"},
+		{"Due Date", "2026-11-02"},
+		{"Author", "Ms. Frizzle"},
+		{"Rounding", "2dp"},
+		{"Tolerance", "1%"},
+		{"Input", "From Unit", "To Unit"},
+		{"100", "liters", "gallons"},
+	}
+
+	ws, err := NewWorksheet(testData)
+	assert.NoError(t, err)
+	assert.Len(t, ws.Questions, 1)
+
+	assert.Equal(t, "Unit Quiz", ws.Metadata.Title)
+	assert.Equal(t, "Chemistry 101", ws.Metadata.Course)
+	assert.Equal(t, "B", ws.Metadata.Section)
+	assert.Equal(t, "2026-11-02", ws.Metadata.DueDate)
+	assert.Equal(t, "Ms. Frizzle", ws.Metadata.Author)
+	assert.Equal(t, Rounding{HalfUp, Places, 2}, *ws.Metadata.Rounding)
+	assert.Equal(t, Tolerance{Percent, 1}, ws.DefaultTolerance)
+
+	// the key is rounded to the worksheet's decimal places
+	assert.Equal(t, 26.42, *ws.Questions[0].CorrectAnswer)
+
+	assert.Equal(t, [][]string{
+		{"Title", "Unit Quiz"},
+		{"Course", "Chemistry 101"},
+		{"Section", "B"},
+		{"Due Date", "2026-11-02"},
+		{"Author", "Ms. Frizzle"},
+		{"Rounding", "2dp"},
+		{"Tolerance", "1%"},
+	}, ws.Metadata.ToGrid())
+
+	testData[5][1] = "two"
+	_, err = NewWorksheet(testData)
+	assert.Error(t, err)
+}
+
+func TestMetadataSpacerRows(t *testing.T) {
+	// the layout results are written in, with a blank row before the header
+	for _, spacer := range [][]string{{}, {"", "", ""}} {
+		testData := [][]string{
+			{"Title", "Quiz 1"},
+			spacer,
+			{"Input", "From Unit", "To Unit"},
+			{"100", "liters", "gallons"},
+		}
+		ws, err := NewWorksheet(testData)
+		assert.NoError(t, err)
+		assert.Equal(t, "Quiz 1", ws.Metadata.Title)
+		assert.Len(t, ws.Questions, 1)
+		assert.Empty(t, LintWorksheet(testData))
+	}
+
+	ws, err := NewWorksheet([][]string{{}, {"100", "liters", "gallons"}})
+	assert.NoError(t, err)
+	assert.Len(t, ws.Questions, 1)
+}
+
+func TestWorksheetRoundingExactGrading(t *testing.T) {
+	ws, _ := NewWorksheet([][]string{
+		{"Rounding", "0"},
+		{"100", "liters", "gallons"},
+	})
+	assert.Equal(t, 26., *ws.Questions[0].CorrectAnswer)
+	assert.True(t, ws.Accepts(0, 26.4))
+	assert.False(t, ws.Accepts(0, 26.6))
+}
+
+func TestResultsShowMetadata(t *testing.T) {
+	ws, _ := NewWorksheet([][]string{
+		{"Title", "Unit Quiz"},
+		{"1.1", "liters", "cups"},
+	})
+	submissions, _ := NewSubmissionList([][]string{{"Doug Doenges", "4.6"}})
+
+	res := GetResults(ws, submissions)
+	gridDisplay := res.ToGridDisplay()
+	assert.Equal(t, []string{"Title", "Unit Quiz"}, gridDisplay[0])
+	assert.Equal(t, []string{}, gridDisplay[1])
+	assert.Equal(t, "Input", gridDisplay[2][0])
+}
@@ -0,0 +1,188 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Quantity is a value carrying its unit. An empty unit is a dimensionless number.
+type Quantity struct {
+	Value float64
+	Unit  string
+}
+
+func NewQuantity(val float64, unit string) (Quantity, error) {
+	unit = strings.TrimSpace(strings.ToLower(unit))
+	if unit != "" {
+		if _, _, ok := lookupUnit(unit); !ok {
+			return Quantity{}, fmt.Errorf("unknown unit '%s'", unit)
+		}
+	}
+	return Quantity{val, unit}, nil
+}
+
+// parseQuantity reads a value followed by its unit, e.g. "0.5 gallons".
+func parseQuantity(s string) (Quantity, error) {
+	valStr, unit, _ := strings.Cut(strings.TrimSpace(s), " ")
+	val, err := strconv.ParseFloat(valStr, 64)
+	if err != nil {
+		return Quantity{}, fmt.Errorf("invalid quantity '%s'", s)
+	}
+	return NewQuantity(val, unit)
+}
+
+func (q Quantity) String() string {
+	valStr := strconv.FormatFloat(q.Value, 'f', -1, 64)
+	if q.Unit == "" {
+		return valStr
+	}
+	return valStr + " " + q.Unit
+}
+
+// Dimension reports the dimension of the quantity's unit, or "" when dimensionless.
+func (q Quantity) Dimension() string {
+	dimension, _, _ := lookupUnit(q.Unit)
+	return dimension
+}
+
+// To converts the quantity to another unit of the same dimension without rounding.
+func (q Quantity) To(unit string) (Quantity, error) {
+	unit = strings.TrimSpace(strings.ToLower(unit))
+	val, err := convert(q.Unit, unit, q.Value)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{val, unit}, nil
+}
+
+func (q Quantity) Add(o Quantity) (Quantity, error) {
+	other, err := q.sameUnit(o, "add")
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{q.Value + other.Value, q.Unit}, nil
+}
+
+func (q Quantity) Sub(o Quantity) (Quantity, error) {
+	other, err := q.sameUnit(o, "subtract")
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{q.Value - other.Value, q.Unit}, nil
+}
+
+// Mul scales by a dimensionless quantity or multiplies a rate by its time,
+// e.g. liters per minute * hours = liters.
+func (q Quantity) Mul(o Quantity) (Quantity, error) {
+	if err := checkLinear(q, o); err != nil {
+		return Quantity{}, err
+	}
+
+	switch {
+	case o.Unit == "":
+		return Quantity{q.Value * o.Value, q.Unit}, nil
+	case q.Unit == "":
+		return Quantity{q.Value * o.Value, o.Unit}, nil
+	}
+
+	if numerator, denominator, ok := splitRate(q.Unit); ok {
+		if t, err := o.To(denominator); err == nil {
+			return Quantity{q.Value * t.Value, numerator}, nil
+		}
+	}
+	if numerator, denominator, ok := splitRate(o.Unit); ok {
+		if t, err := q.To(denominator); err == nil {
+			return Quantity{t.Value * o.Value, numerator}, nil
+		}
+	}
+
+	return Quantity{}, fmt.Errorf("cannot multiply %s by %s", q.Unit, o.Unit)
+}
+
+// Div scales by a dimensionless quantity, takes the ratio of two quantities of
+// the same dimension, or forms and undoes rates, e.g. liters / minutes = liters per minute.
+func (q Quantity) Div(o Quantity) (Quantity, error) {
+	if err := checkLinear(q, o); err != nil {
+		return Quantity{}, err
+	}
+	if o.Value == 0 {
+		return Quantity{}, fmt.Errorf("cannot divide %s by zero", q)
+	}
+
+	if o.Unit == "" {
+		return Quantity{q.Value / o.Value, q.Unit}, nil
+	}
+	if other, err := o.To(q.Unit); err == nil {
+		return Quantity{q.Value / other.Value, ""}, nil
+	}
+
+	if o.Dimension() == "time" {
+		rate := q.Unit + " per " + strings.TrimSuffix(o.Unit, "s")
+		if _, _, ok := lookupUnit(rate); ok {
+			return Quantity{q.Value / o.Value, rate}, nil
+		}
+	}
+	if numerator, denominator, ok := splitRate(o.Unit); ok {
+		if v, err := q.To(numerator); err == nil {
+			return Quantity{v.Value / o.Value, denominator}, nil
+		}
+	}
+
+	return Quantity{}, fmt.Errorf("cannot divide %s by %s", q.Unit, o.Unit)
+}
+
+// compareEpsilon absorbs floating point error picked up while converting.
+const compareEpsilon = 1e-9
+
+// Compare returns -1, 0 or 1 as q is less than, equal to or greater than o.
+func (q Quantity) Compare(o Quantity) (int, error) {
+	other, err := o.To(q.Unit)
+	if err != nil {
+		return 0, fmt.Errorf("cannot compare %s with %s: %v", q.Unit, o.Unit, err)
+	}
+	switch {
+	case math.Abs(q.Value-other.Value) <= compareEpsilon*math.Max(1, math.Max(math.Abs(q.Value), math.Abs(other.Value))):
+		return 0, nil
+	case q.Value < other.Value:
+		return -1, nil
+	default:
+		return 1, nil
+	}
+}
+
+func (q Quantity) sameUnit(o Quantity, op string) (Quantity, error) {
+	if err := checkLinear(q, o); err != nil {
+		return Quantity{}, err
+	}
+	other, err := o.To(q.Unit)
+	if err != nil {
+		return Quantity{}, fmt.Errorf("cannot %s %s and %s: %v", op, q.Unit, o.Unit, err)
+	}
+	return other, nil
+}
+
+// checkLinear rejects arithmetic on offset scales such as celsius, where
+// the result would depend on which scale the operands were written in.
+func checkLinear(quantities ...Quantity) error {
+	for _, q := range quantities {
+		if _, s, ok := lookupUnit(q.Unit); ok && s.offset != 0 {
+			return fmt.Errorf("arithmetic is not supported for %s", q.Unit)
+		}
+	}
+	return nil
+}
+
+// splitRate splits a rate unit such as "liters per minute" into its volume
+// unit and its time unit, "liters" and "minutes".
+func splitRate(unit string) (string, string, bool) {
+	numerator, denominator, found := strings.Cut(unit, " per ")
+	if !found {
+		return "", "", false
+	}
+	if dimension, _, ok := lookupUnit(denominator + "s"); ok && dimension == "time" {
+		return numerator, denominator + "s", true
+	}
+	return "", "", false
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewQuantity(t *testing.T) {
+	q, err := NewQuantity(2, " Liters ")
+	assert.NoError(t, err)
+	assert.Equal(t, Quantity{2, "liters"}, q)
+	assert.Equal(t, "volume", q.Dimension())
+	assert.Equal(t, "2 liters", q.String())
+
+	_, err = NewQuantity(2, "not a unit")
+	assert.Error(t, err)
+}
+
+func TestQuantityTo(t *testing.T) {
+	q := Quantity{1, "gallons"}
+	cups, err := q.To("cups")
+	assert.NoError(t, err)
+	assert.Equal(t, Quantity{16, "cups"}, cups)
+
+	// unlike ConvertUnits the value is not rounded
+	cubicFeet, err := Quantity{100, "tablespoons"}.To("cubic feet")
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.0522, cubicFeet.Value, 0.0001)
+
+	_, err = q.To("kelvin")
+	assert.Error(t, err)
+}
+
+func TestQuantityAddSub(t *testing.T) {
+	sum, err := Quantity{1, "gallons"}.Add(Quantity{2, "quarts"})
+	assert.NoError(t, err)
+	assert.Equal(t, Quantity{1.5, "gallons"}, sum)
+
+	diff, err := Quantity{1, "cubic feet"}.Sub(Quantity{728, "cubic inches"})
+	assert.NoError(t, err)
+	assert.Equal(t, Quantity{1000.0 / 1728, "cubic feet"}, diff)
+
+	_, err = Quantity{1, "gallons"}.Add(Quantity{1, "kelvin"})
+	assert.Error(t, err)
+
+	_, err = Quantity{10, "celsius"}.Add(Quantity{10, "celsius"})
+	assert.Error(t, err)
+}
+
+func TestQuantityMulDiv(t *testing.T) {
+	doubled, err := Quantity{3, "liters"}.Mul(Quantity{2, ""})
+	assert.NoError(t, err)
+	assert.Equal(t, Quantity{6, "liters"}, doubled)
+
+	rate, err := Quantity{120, "liters"}.Div(Quantity{2, "minutes"})
+	assert.NoError(t, err)
+	assert.Equal(t, Quantity{60, "liters per minute"}, rate)
+
+	volume, err := rate.Mul(Quantity{1, "hours"})
+	assert.NoError(t, err)
+	assert.Equal(t, Quantity{3600, "liters"}, volume)
+
+	duration, err := Quantity{30, "liters"}.Div(rate)
+	assert.NoError(t, err)
+	assert.Equal(t, Quantity{0.5, "minutes"}, duration)
+
+	ratio, err := Quantity{1, "gallons"}.Div(Quantity{1, "cups"})
+	assert.NoError(t, err)
+	assert.Equal(t, Quantity{16, ""}, ratio)
+
+	_, err = Quantity{1, "gallons"}.Mul(Quantity{1, "liters"})
+	assert.Error(t, err)
+
+	_, err = Quantity{1, "gallons"}.Div(Quantity{0, ""})
+	assert.Error(t, err)
+
+	_, err = Quantity{1, "celsius"}.Mul(Quantity{2, ""})
+	assert.Error(t, err)
+}
+
+func TestQuantityCompare(t *testing.T) {
+	cmp, err := Quantity{2, "liters"}.Compare(Quantity{0.5, "gallons"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cmp)
+
+	cmp, err = Quantity{0, "celsius"}.Compare(Quantity{32, "fahrenheit"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cmp)
+
+	cmp, err = Quantity{1, "cups"}.Compare(Quantity{1, "pints"})
+	assert.NoError(t, err)
+	assert.Equal(t, -1, cmp)
+
+	_, err = Quantity{1, "cups"}.Compare(Quantity{1, "kelvin"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,188 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type QuestionType string
+
+const (
+	// convert Input from From Unit to To Unit
+	ConversionQuestion QuestionType = "conversion"
+	// Input is already in To Unit; find the value in From Unit that converts to it
+	ReverseQuestion QuestionType = "reverse"
+	// which is larger: Input in From Unit or one of the quantities in Choices
+	ComparisonQuestion QuestionType = "comparison"
+	// which of the units in Choices does Input convert to from From Unit,
+	// given the converted value in To Unit
+	ChooseUnitQuestion QuestionType = "choose unit"
+	// which of the values in Choices is Input converted from From Unit to To Unit
+	MultipleChoiceQuestion QuestionType = "multiple choice"
+)
+
+var questionTypes = map[QuestionType]struct{}{
+	ConversionQuestion:     {},
+	ReverseQuestion:        {},
+	ComparisonQuestion:     {},
+	ChooseUnitQuestion:     {},
+	MultipleChoiceQuestion: {},
+}
+
+// choiceSeparator splits the Choices column, e.g. "0.5 gallons;3 cups".
+const choiceSeparator = ";"
+
+func parseQuestionType(s string) (QuestionType, error) {
+	s = strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	if s == "" {
+		return ConversionQuestion, nil
+	}
+	if _, ok := questionTypes[QuestionType(s)]; !ok {
+		return "", fmt.Errorf("unknown question type '%s'", s)
+	}
+	return QuestionType(s), nil
+}
+
+func parseChoices(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	choices := strings.Split(s, choiceSeparator)
+	for idx := range choices {
+		choices[idx] = strings.TrimSpace(choices[idx])
+	}
+	return choices
+}
+
+// isChoice reports whether students answer with the 1-based number of a choice.
+func (t QuestionType) isChoice() bool {
+	return t == ComparisonQuestion || t == ChooseUnitQuestion || t == MultipleChoiceQuestion
+}
+
+// answerQuestion computes the unrounded answer of a question according to its type.
+// Choice questions answer with the number of the correct choice, comparing
+// quantities after rounding them with round.
+func answerQuestion(q Question, conv Converter, round func(float64) float64) (float64, error) {
+	switch q.Type {
+	case ReverseQuestion:
+		return conv.Convert(q.TargetUoM, q.InputUoM, q.Input)
+	case ComparisonQuestion:
+		return largestChoice(q, conv)
+	case ChooseUnitQuestion:
+		return matchingUnit(q, conv, round)
+	case MultipleChoiceQuestion:
+		return matchingChoice(q, conv, round)
+	default:
+		return conv.Convert(q.InputUoM, q.TargetUoM, q.Input)
+	}
+}
+
+// largestChoice numbers the question's own quantity 1 and its Choices from 2,
+// and returns the number of the largest one. Quantities tie only when their
+// exact values are equal, however the worksheet rounds.
+func largestChoice(q Question, conv Converter) (float64, error) {
+	if len(q.Choices) == 0 {
+		return -1, fmt.Errorf("nothing to compare with")
+	}
+
+	largestIdx, largest, tied := 1, q.Input, false
+	for idx, choice := range q.Choices {
+		quantity, err := parseQuantity(choice)
+		if err != nil {
+			return -1, err
+		}
+		val, err := conv.Convert(quantity.Unit, q.InputUoM, quantity.Value)
+		if err != nil {
+			return -1, err
+		}
+		switch {
+		case math.Abs(val-largest) <= floatSlack(largest):
+			tied = true
+		case val > largest:
+			largestIdx, largest, tied = idx+2, val, false
+		}
+	}
+	if tied {
+		return -1, fmt.Errorf("more than one quantity is the largest")
+	}
+
+	return float64(largestIdx), nil
+}
+
+// matchingUnit returns the number of the choice that is the unit Input
+// converts to, given the converted value in To Unit. Exactly one choice must
+// convert to the value after rounding.
+func matchingUnit(q Question, conv Converter, round func(float64) float64) (float64, error) {
+	converted, err := strconv.ParseFloat(q.TargetUoM, 64)
+	if err != nil {
+		return -1, fmt.Errorf("invalid converted value '%s': give the value Input converts to as To Unit", q.TargetUoM)
+	}
+	if _, _, ok := lookupUnit(q.InputUoM); !ok {
+		return -1, fmt.Errorf("unknown unit '%s'", q.InputUoM)
+	}
+
+	match := -1
+	for idx, choice := range q.Choices {
+		if _, _, ok := lookupUnit(choice); !ok {
+			return -1, fmt.Errorf("unknown unit '%s'", choice)
+		}
+		// units of another dimension are wrong choices
+		val, err := conv.Convert(q.InputUoM, choice, q.Input)
+		if err != nil || round(val) != round(converted) {
+			continue
+		}
+		if match != -1 {
+			return -1, fmt.Errorf("more than one choice converts to %s", q.TargetUoM)
+		}
+		match = idx + 1
+	}
+	if match == -1 {
+		return -1, fmt.Errorf("none of the choices converts to %s", q.TargetUoM)
+	}
+	return float64(match), nil
+}
+
+// matchingChoice returns the number of the choice that is the converted
+// Input. Exactly one choice must equal it after rounding.
+func matchingChoice(q Question, conv Converter, round func(float64) float64) (float64, error) {
+	exact, err := conv.Convert(q.InputUoM, q.TargetUoM, q.Input)
+	if err != nil {
+		return -1, err
+	}
+	key := strconv.FormatFloat(round(exact), 'f', -1, 64)
+
+	match := -1
+	for idx, choice := range q.Choices {
+		val, err := strconv.ParseFloat(choice, 64)
+		if err != nil {
+			return -1, fmt.Errorf("invalid choice '%s'", choice)
+		}
+		if round(val) != round(exact) {
+			continue
+		}
+		if match != -1 {
+			return -1, fmt.Errorf("more than one choice is %s", key)
+		}
+		match = idx + 1
+	}
+	if match == -1 {
+		return -1, fmt.Errorf("none of the choices is %s", key)
+	}
+	return float64(match), nil
+}
+
+// choiceLabel describes the choice a 1-based number refers to.
+func (q *Question) choiceLabel(number int) string {
+	if q.Type == ComparisonQuestion {
+		if number == 1 {
+			return strconv.FormatFloat(q.Input, 'f', -1, 64) + " " + q.InputUoM
+		}
+		number--
+	}
+	if number < 1 || number > len(q.Choices) {
+		return ""
+	}
+	return q.Choices[number-1]
+}
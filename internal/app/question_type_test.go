@@ -0,0 +1,96 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuestionType(t *testing.T) {
+	qt, err := parseQuestionType("")
+	assert.NoError(t, err)
+	assert.Equal(t, ConversionQuestion, qt)
+
+	qt, err = parseQuestionType(" Multiple  Choice ")
+	assert.NoError(t, err)
+	assert.Equal(t, MultipleChoiceQuestion, qt)
+
+	_, err = parseQuestionType("essay")
+	assert.Error(t, err)
+}
+
+func TestQuestionTypes(t *testing.T) {
+	testData := [][]string{
+		{"Type", "Input", "From Unit", "To Unit", "Choices"},
+		{"reverse", "212", "celsius", "fahrenheit", ""},
+		{"comparison", "2", "liters", "", "0.5 gallons"},
+		{"comparison", "1", "cups", "", "8 fluid ounces"},
+		{"choose unit", "1", "gallons", "4", "liters; Quarts ;cups"},
+		{"multiple choice", "100", "liters", "gallons", "28;26.4;0.26"},
+		{"multiple choice", "100", "liters", "gallons", "28;0.26"},
+	}
+
+	ws, err := NewWorksheet(testData)
+	assert.NoError(t, err)
+	key := ws.Key()
+
+	assert.Equal(t, 100., *key[0])
+	assert.Equal(t, 1., *key[1])
+	assert.Equal(t, []string{"2", "liters", "", "1 (2 liters)"}, ws.Questions[1].ToGrid())
+	assert.Nil(t, key[2], "equal quantities have no larger one")
+	assert.Equal(t, 2., *key[3])
+	assert.Equal(t, []string{"1", "gallons", "4", "2 (Quarts)"}, ws.Questions[3].ToGrid())
+	assert.Equal(t, 2., *key[4])
+	assert.Nil(t, key[5], "no choice is correct")
+
+	// choices must match exactly, whatever the tolerance
+	ws.DefaultTolerance = Tolerance{Absolute, 1}
+	assert.True(t, ws.Accepts(4, 2))
+	assert.False(t, ws.Accepts(4, 1.5))
+	assert.True(t, ws.Accepts(0, 99.5))
+
+	testData[1][0] = "essay"
+	_, err = NewWorksheet(testData)
+	assert.Error(t, err)
+}
+
+func TestChooseUnitQuestion(t *testing.T) {
+	ws, err := NewWorksheet([][]string{
+		{"Type", "Input", "From Unit", "To Unit", "Choices"},
+		{"choose unit", "1", "liters", "1000", "milliliters;cubic centimeters;cups"},
+		{"choose unit", "1", "liters", "quarts", "liters;quarts"},
+		{"choose unit", "1", "liters", "3", "liters;quarts"},
+		{"choose unit", "2", "cups", "32", "seconds;tablespoons"},
+		{"choose unit", "2", "cups", "32", "spoons;tablespoons"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "more than one choice converts to 1000", ws.Questions[0].Problem)
+	assert.Equal(t, "invalid converted value 'quarts': give the value Input converts to as To Unit", ws.Questions[1].Problem)
+	assert.Equal(t, "none of the choices converts to 3", ws.Questions[2].Problem)
+	assert.Equal(t, 2., *ws.Questions[3].CorrectAnswer)
+	assert.Equal(t, "unknown unit 'spoons'", ws.Questions[4].Problem)
+}
+
+func TestMultipleChoiceQuestion(t *testing.T) {
+	ws, err := NewWorksheet([][]string{
+		{"Type", "Input", "From Unit", "To Unit", "Choices"},
+		{"multiple choice", "100", "liters", "gallons", "26.4;26.42"},
+		{"multiple choice", "100", "liters", "gallons", "26.4;about 26"},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, ws.Questions[0].CorrectAnswer)
+	assert.Equal(t, "more than one choice is 26.4", ws.Questions[0].Problem)
+	assert.Equal(t, "invalid choice 'about 26'", ws.Questions[1].Problem)
+}
+
+func TestComparisonTies(t *testing.T) {
+	// different quantities that round alike are not tied
+	ws, err := NewWorksheet([][]string{
+		{"Type", "Input", "From Unit", "To Unit", "Choices"},
+		{"comparison", "0.04", "liters", "", "0.01 liters"},
+		{"comparison", "40", "milliliters", "", "0.04 liters"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1., *ws.Questions[0].CorrectAnswer)
+	assert.Nil(t, ws.Questions[1].CorrectAnswer)
+}
@@ -2,12 +2,13 @@ package app
 
 type Results struct {
 	input             Worksheet
+	variants          []Worksheet // per-student worksheets, replacing input
 	gradedSubmissions []Submission
 }
 
 func GetResults(ws Worksheet, submissions []Submission) Results {
 	for idx := range submissions {
-		submissions[idx].Grade(ws.Key())
+		submissions[idx].GradeWorksheet(ws)
 	}
 	return Results{
 		input:             ws,
@@ -16,7 +17,12 @@ func GetResults(ws Worksheet, submissions []Submission) Results {
 }
 
 func (r *Results) ToGridDisplay() [][]string {
+	if len(r.variants) > 0 {
+		return r.variantGridDisplay()
+	}
+
 	gridDisplay := make([][]string, 0, len(r.input.Questions)+1)
+	gridDisplay = appendMetadata(gridDisplay, r.input.Metadata)
 
 	const spacer = ""
 	const colsPerStudent = 2
@@ -47,5 +53,34 @@ func (r *Results) ToGridDisplay() [][]string {
 		gridDisplay = append(gridDisplay, row)
 	}
 
+	// subtotal each group, then total each student's score
+	if r.input.HasGroups() {
+		for groupIdx, group := range r.input.Groups {
+			groupRow := make([]string, 0, numCols)
+			groupRow = append(groupRow, "", "", "", groupLabel(group.Name), spacer)
+			for _, submission := range r.gradedSubmissions {
+				groupRow = append(groupRow, submission.GroupScoreToGrid(groupIdx)...)
+			}
+			gridDisplay = append(gridDisplay, groupRow)
+		}
+	}
+	scoreRow := make([]string, 0, numCols)
+	scoreRow = append(scoreRow, "", "", "", "Score", spacer)
+	for _, submission := range r.gradedSubmissions {
+		scoreRow = append(scoreRow, submission.ScoreToGrid()...)
+	}
+	gridDisplay = append(gridDisplay, scoreRow)
+
 	return gridDisplay
 }
+
+// appendMetadata prints the worksheet's metadata above the results,
+// separated from them by an empty row.
+func appendMetadata(gridDisplay [][]string, metadata Metadata) [][]string {
+	metadataRows := metadata.ToGrid()
+	if len(metadataRows) == 0 {
+		return gridDisplay
+	}
+	gridDisplay = append(gridDisplay, metadataRows...)
+	return append(gridDisplay, []string{})
+}
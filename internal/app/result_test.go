@@ -23,6 +23,7 @@ func TestNewResultsToGridDisplay(t *testing.T) {
 	testDisplay := [][]string{
 		{"Input", "From Unit", "To Unit", "Correct Answer", "", testSubmissions[0][0], ""},
 		{testWs[0][0], testWs[0][1], testWs[0][2], "4.6", "", testSubmissions[0][1], "Incorrect"},
+		{"", "", "", "Score", "", "0/1", "0%"},
 	}
 	assert.Equal(t, testDisplay, gridDisplay)
 }
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type RoundingMode string
+
+const (
+	HalfUp   RoundingMode = "half-up"
+	HalfEven RoundingMode = "half-even"
+	Truncate RoundingMode = "truncate"
+)
+
+type Precision string
+
+const (
+	Places             Precision = "dp"
+	SignificantFigures Precision = "sf"
+	// Auto keeps at least one decimal place and autoSignificantFigures
+	// significant figures, so small answers are not rounded away.
+	Auto Precision = "auto"
+)
+
+const autoSignificantFigures = 3
+
+// Rounding is how answer keys and responses are rounded before they are compared.
+type Rounding struct {
+	Mode      RoundingMode
+	Precision Precision
+	Digits    int // decimal places or significant figures, unused by Auto
+}
+
+// DefaultRounding rounds half-up to one decimal place.
+var DefaultRounding = Rounding{HalfUp, Places, 1}
+
+var roundingModes = []RoundingMode{HalfUp, HalfEven, Truncate}
+
+// ParseRounding reads an optional mode followed by a precision, e.g. "2dp",
+// "3sf", "auto" or "half-even 2dp". A bare number is decimal places and a
+// missing precision is one decimal place.
+func ParseRounding(s string) (Rounding, error) {
+	r := DefaultRounding
+	rest := strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	rest = strings.NewReplacer("half up", "half-up", "half even", "half-even", "_", "-").Replace(rest)
+
+	for _, mode := range roundingModes {
+		if strings.HasPrefix(rest, string(mode)) {
+			r.Mode = mode
+			rest = strings.TrimSpace(strings.TrimPrefix(rest, string(mode)))
+			break
+		}
+	}
+
+	switch {
+	case rest == "":
+	case rest == string(Auto):
+		r.Precision, r.Digits = Auto, 0
+	default:
+		r.Precision = Places
+		if strings.HasSuffix(rest, string(SignificantFigures)) {
+			r.Precision = SignificantFigures
+		}
+		digits, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(rest, string(r.Precision))))
+		if err != nil || digits < 0 || (r.Precision == SignificantFigures && digits == 0) {
+			return Rounding{}, fmt.Errorf("invalid rounding '%s': give decimal places (2dp), significant figures (3sf) or auto", s)
+		}
+		r.Digits = digits
+	}
+
+	return r, nil
+}
+
+func (r Rounding) String() string {
+	precision := string(r.Precision)
+	if r.Precision != Auto {
+		precision = strconv.Itoa(r.Digits) + precision
+	}
+	if r.Mode == HalfUp {
+		return precision
+	}
+	return string(r.Mode) + " " + precision
+}
+
+// Round rounds a value according to the rounding mode and precision.
+func (r Rounding) Round(value float64) float64 {
+	if value == 0 || math.IsInf(value, 0) || math.IsNaN(value) {
+		return value
+	}
+
+	places := r.Digits
+	switch r.Precision {
+	case SignificantFigures:
+		places = r.Digits - 1 - magnitude(value)
+	case Auto:
+		places = max(1, autoSignificantFigures-1-magnitude(value))
+	}
+
+	// divide by whole powers of ten, which are exact, instead of multiplying by fractions
+	pow := math.Pow(10, math.Abs(float64(places)))
+	scaled := value * pow
+	if places < 0 {
+		scaled = value / pow
+	}
+	switch r.Mode {
+	case HalfEven:
+		scaled = math.RoundToEven(scaled)
+	case Truncate:
+		// nudge away from zero so 0.29 is not truncated to 0.28
+		scaled = math.Trunc(scaled + math.Copysign(floatSlack(scaled), scaled))
+	default:
+		scaled = math.Floor(scaled + 0.5)
+	}
+	if places < 0 {
+		return scaled * pow
+	}
+	return scaled / pow
+}
+
+// magnitude is the power of ten of a value's leading digit.
+func magnitude(value float64) int {
+	return int(math.Floor(math.Log10(math.Abs(value))))
+}
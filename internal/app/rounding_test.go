@@ -0,0 +1,86 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRounding(t *testing.T) {
+	cases := map[string]Rounding{
+		"":              DefaultRounding,
+		"2":             {HalfUp, Places, 2},
+		"0dp":           {HalfUp, Places, 0},
+		" 3 SF ":        {HalfUp, SignificantFigures, 3},
+		"auto":          {HalfUp, Auto, 0},
+		"half-even 2dp": {HalfEven, Places, 2},
+		"Half Even":     {HalfEven, Places, 1},
+		"truncate 2sf":  {Truncate, SignificantFigures, 2},
+		"truncate auto": {Truncate, Auto, 0},
+		"half_up 1dp":   {HalfUp, Places, 1},
+	}
+	for s, expected := range cases {
+		r, err := ParseRounding(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, r, s)
+	}
+
+	for _, s := range []string{"lots", "-1dp", "0sf", "round 2dp", "2.5dp"} {
+		_, err := ParseRounding(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestRoundingString(t *testing.T) {
+	for _, s := range []string{"2dp", "3sf", "auto", "half-even 0dp", "truncate auto"} {
+		r, _ := ParseRounding(s)
+		assert.Equal(t, s, r.String())
+	}
+}
+
+func TestRoundingRound(t *testing.T) {
+	cases := []struct {
+		rounding Rounding
+		value    float64
+		expected float64
+	}{
+		{DefaultRounding, 26.417, 26.4},
+		{DefaultRounding, 0.0522, 0.1},
+		{Rounding{HalfUp, Places, 2}, 2.345, 2.35},
+		{Rounding{HalfEven, Places, 2}, 0.125, 0.12},
+		{Rounding{HalfEven, Places, 0}, 3.5, 4},
+		{Rounding{Truncate, Places, 2}, 0.29, 0.29},
+		{Rounding{Truncate, Places, 1}, 26.49, 26.4},
+		{Rounding{Truncate, Places, 1}, -26.49, -26.4},
+		{Rounding{HalfUp, SignificantFigures, 3}, 0.052218, 0.0522},
+		{Rounding{HalfUp, SignificantFigures, 2}, 1443.75, 1400},
+		{Rounding{HalfUp, Auto, 0}, 0.052218, 0.0522},
+		{Rounding{HalfUp, Auto, 0}, 1443.75, 1443.8},
+		{Rounding{HalfUp, Auto, 0}, 2.6417, 2.64},
+		{Rounding{HalfUp, SignificantFigures, 3}, 0, 0},
+	}
+	for _, c := range cases {
+		assert.InDelta(t, c.expected, c.rounding.Round(c.value), 1e-12, "%s of %v", c.rounding, c.value)
+	}
+}
+
+func TestWorksheetRounding(t *testing.T) {
+	data := [][]string{
+		{"Rounding", "auto"},
+		{"100", "tablespoons", "cubic feet"},
+	}
+	ws, err := NewWorksheet(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0522, *ws.Questions[0].CorrectAnswer)
+	// responses are rounded the same way as the key
+	assert.True(t, ws.Accepts(0, 0.05224))
+	assert.False(t, ws.Accepts(0, 0.1))
+
+	// the run's rounding overrides the worksheet's
+	truncate := Rounding{Truncate, Places, 1}
+	ws, err = RoundedWorksheetParser(TableConverter{}, &truncate)(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 0., *ws.Questions[0].CorrectAnswer)
+	assert.Equal(t, "Rounding", ws.Metadata.ToGrid()[0][0])
+	assert.Equal(t, "truncate 1dp", ws.Metadata.ToGrid()[0][1])
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SIConverterName selects SIConverter, e.g. to cross-check the table.
+const SIConverterName = "si"
+
+// SIConverter is a backend independent of unitConversions: volumes are
+// converted through cubic meters using their published definitions, times
+// through seconds and temperatures through the Celsius formula of each scale.
+type SIConverter struct{}
+
+// siVolumes is the size of each volume unit in cubic meters.
+var siVolumes = map[string]float64{
+	"cubic meters":      1,
+	"liters":            1e-3,
+	"milliliters":       1e-6,
+	"cubic centimeters": 1e-6,
+	"cubic inches":      16.387064e-6,
+	"cubic feet":        28.316846592e-3,
+	"cubic yards":       0.764554857984,
+	"gallons":           3.785411784e-3,
+	"quarts":            0.946352946e-3,
+	"pints":             0.473176473e-3,
+	"cups":              236.5882365e-6,
+	"fluid ounces":      29.5735295625e-6,
+	"tablespoons":       14.78676478125e-6,
+	"teaspoons":         4.92892159375e-6,
+	"barrels":           0.158987294928,
+}
+
+// siTimes is the length of each time unit in seconds.
+var siTimes = map[string]float64{
+	"seconds": 1,
+	"minutes": 60,
+	"hours":   3600,
+	"days":    86400,
+}
+
+// siTemperatures converts each temperature scale to and from Celsius.
+var siTemperatures = map[string]struct{ toCelsius, fromCelsius func(float64) float64 }{
+	"celsius": {
+		func(c float64) float64 { return c },
+		func(c float64) float64 { return c }},
+	"kelvin": {
+		func(k float64) float64 { return k - 273.15 },
+		func(c float64) float64 { return c + 273.15 }},
+	"fahrenheit": {
+		func(f float64) float64 { return (f - 32) * 5 / 9 },
+		func(c float64) float64 { return c*9/5 + 32 }},
+	"rankine": {
+		func(r float64) float64 { return (r - 491.67) * 5 / 9 },
+		func(c float64) float64 { return (c + 273.15) * 9 / 5 }},
+	"reaumur": {
+		func(re float64) float64 { return re * 5 / 4 },
+		func(c float64) float64 { return c * 4 / 5 }},
+	"delisle": {
+		func(de float64) float64 { return 100 - de*2/3 },
+		func(c float64) float64 { return (100 - c) * 3 / 2 }},
+	"newton": {
+		func(n float64) float64 { return n * 100 / 33 },
+		func(c float64) float64 { return c * 33 / 100 }},
+	"romer": {
+		func(ro float64) float64 { return (ro - 7.5) * 40 / 21 },
+		func(c float64) float64 { return c*21/40 + 7.5 }},
+}
+
+func (SIConverter) Convert(from, to string, val float64) (float64, error) {
+	from, to = siUnitName(from), siUnitName(to)
+	if fromTemp, ok := siTemperatures[from]; ok {
+		if toTemp, ok := siTemperatures[to]; ok {
+			return toTemp.fromCelsius(fromTemp.toCelsius(val)), nil
+		}
+	}
+	fromSize, fromOk := siSize(from)
+	toSize, toOk := siSize(to)
+	if fromOk && toOk && siDimension(from) == siDimension(to) {
+		return val * fromSize / toSize, nil
+	}
+	return -1, fmt.Errorf("invalid conversion: from %s, to %s", from, to)
+}
+
+func siUnitName(unit string) string {
+	unit = strings.ToLower(strings.TrimSpace(unit))
+	if alias, ok := unitAliases[unit]; ok {
+		return alias
+	}
+	return unit
+}
+
+// siSize is a volume in cubic meters, a time in seconds or a flow rate in
+// cubic meters per second.
+func siSize(unit string) (float64, bool) {
+	if volume, ok := siVolumes[unit]; ok {
+		return volume, true
+	}
+	if time, ok := siTimes[unit]; ok {
+		return time, true
+	}
+	volumeUnit, timeUnit, found := strings.Cut(unit, " per ")
+	volume, volumeOk := siVolumes[volumeUnit]
+	time, timeOk := siTimes[timeUnit+"s"]
+	return volume / time, found && volumeOk && timeOk
+}
+
+func siDimension(unit string) string {
+	switch {
+	case strings.Contains(unit, " per "):
+		return "flow rate"
+	case siTimes[unit] != 0:
+		return "time"
+	default:
+		return "volume"
+	}
+}
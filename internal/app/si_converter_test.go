@@ -0,0 +1,52 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSIConverter(t *testing.T) {
+	conv, err := GetConverter(SIConverterName)
+	assert.NoError(t, err)
+
+	val, err := conv.Convert("fahrenheit", "rankine", 100)
+	assert.NoError(t, err)
+	assert.InDelta(t, 559.67, val, 1e-9)
+	val, err = conv.Convert("Réaumur", "delisle", 80)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0, val, 1e-9)
+	val, err = conv.Convert("gallons", "cups", 1)
+	assert.NoError(t, err)
+	assert.InDelta(t, 16, val, 1e-9)
+	val, err = conv.Convert("liters per minute", "gallons per hour", 12)
+	assert.NoError(t, err)
+	assert.InDelta(t, 190.2, val, 0.05)
+
+	_, err = conv.Convert("liters", "hours", 1)
+	assert.EqualError(t, err, "invalid conversion: from liters, to hours")
+	_, err = conv.Convert("liters", "celsius", 1)
+	assert.Error(t, err)
+}
+
+// the two backends agree on every pair of units to within floating point error
+func TestSIConverterAgreesWithTable(t *testing.T) {
+	for dimension, scales := range unitConversions {
+		for from := range scales {
+			for to := range scales {
+				table, err := TableConverter{}.Convert(from, to, 50)
+				assert.NoError(t, err)
+				si, err := SIConverter{}.Convert(from, to, 50)
+				assert.NoError(t, err, dimension)
+				assert.InDelta(t, table, si, 1e-9*max(1, table), "%s -> %s", from, to)
+			}
+		}
+	}
+
+	ws, err := NewWorksheet([][]string{
+		{"100", "fahrenheit", "rankine"},
+		{"3", "liters", "cups"},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, CrossCheck(ws, SIConverter{}))
+}
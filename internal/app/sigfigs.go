@@ -0,0 +1,74 @@
+package app
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// significantFigures counts the significant figures of a number as written.
+// Trailing zeros of a whole number without a decimal point are not
+// significant, so "100" has one and "100." has three.
+func significantFigures(text string) (int, bool) {
+	s := strings.TrimLeft(strings.TrimSpace(strings.ToLower(text)), "+-")
+	if mantissa, _, found := strings.Cut(s, "e"); found {
+		s = mantissa
+	}
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return 0, false
+	}
+
+	whole, fraction, hasPoint := strings.Cut(s, ".")
+	digits := strings.TrimLeft(whole+fraction, "0")
+	if digits == "" {
+		// a zero has as many significant figures as decimal places shown
+		return max(1, len(fraction)), true
+	}
+	if !hasPoint {
+		digits = strings.TrimRight(digits, "0")
+	}
+	return len(digits), true
+}
+
+// SignificantFigures is the number of significant figures an answer should
+// have: as many as the input, since conversion factors are exact.
+func (q *Question) SignificantFigures() (int, bool) {
+	if q.Type.isChoice() {
+		return 0, false
+	}
+	return significantFigures(q.InputText)
+}
+
+// Decide grades a response, given as a number and as written. With
+// significant figures grading, a response of the right value must also have
+// the question's significant figures; the value may then be rounded, with
+// the worksheet's rounding mode, to the figures the student gave instead of
+// the answer key's.
+func (ws Worksheet) Decide(questionIdx int, response float64, raw string) Decision {
+	accepted := ws.Accepts(questionIdx, response)
+	q := ws.Questions[questionIdx]
+
+	expected, ok := q.SignificantFigures()
+	given, givenOk := significantFigures(raw)
+	if !ws.Metadata.SignificantFigures || !ok || !givenOk || q.CorrectAnswer == nil {
+		return decisionOf(accepted)
+	}
+
+	if !accepted {
+		rounded := Rounding{ws.rounding().Mode, SignificantFigures, given}.Round(q.exact)
+		if math.Abs(rounded-response) > floatSlack(rounded) {
+			return Incorrect
+		}
+	}
+	if given != expected {
+		return WrongSigFigs
+	}
+	return Correct
+}
+
+func decisionOf(accepted bool) Decision {
+	if accepted {
+		return Correct
+	}
+	return Incorrect
+}
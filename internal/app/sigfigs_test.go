@@ -0,0 +1,94 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignificantFigures(t *testing.T) {
+	cases := map[string]int{
+		"100":     1,
+		"100.":    3,
+		"100.0":   4,
+		"37.80":   4,
+		"0.0522":  3,
+		"-0.0500": 3,
+		"1.20e3":  3,
+		"1200":    2,
+		"0":       1,
+		"0.00":    2,
+		" 212 ":   3,
+	}
+	for text, expected := range cases {
+		figures, ok := significantFigures(text)
+		assert.True(t, ok, text)
+		assert.Equal(t, expected, figures, text)
+	}
+
+	_, ok := significantFigures("abc")
+	assert.False(t, ok)
+	_, ok = significantFigures("")
+	assert.False(t, ok)
+}
+
+func TestWorksheetDecideSignificantFigures(t *testing.T) {
+	ws, err := NewWorksheet([][]string{
+		{"Significant Figures", "yes"},
+		{"Input", "From Unit", "To Unit", "Type", "Choices"},
+		{"100.", "fahrenheit", "celsius", "", ""},
+		{"100", "fahrenheit", "celsius", "", ""},
+		{"1", "gallons", "4", "choose unit", "liters;quarts"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, ws.Metadata.SignificantFigures)
+
+	expected, ok := ws.Questions[0].SignificantFigures()
+	assert.True(t, ok)
+	assert.Equal(t, 3, expected)
+
+	assert.Equal(t, Correct, ws.Decide(0, 37.8, "37.8"))
+	assert.Equal(t, WrongSigFigs, ws.Decide(0, 37.78, "37.78"))
+	assert.Equal(t, WrongSigFigs, ws.Decide(0, 38, "38"))
+	assert.Equal(t, Incorrect, ws.Decide(0, 39, "39"))
+
+	// one significant figure: the value is judged at the figures given
+	assert.Equal(t, Correct, ws.Decide(1, 40, "40"))
+	assert.Equal(t, WrongSigFigs, ws.Decide(1, 37.8, "37.8"))
+	assert.Equal(t, Incorrect, ws.Decide(1, 30, "30"))
+
+	// choice questions have no significant figures
+	assert.Equal(t, Correct, ws.Decide(2, 2, "2.0"))
+
+	ws.Metadata.SignificantFigures = false
+	assert.Equal(t, Correct, ws.Decide(0, 37.78, "37.78"))
+	assert.Equal(t, Incorrect, ws.Decide(1, 40, "40"))
+
+	// the figures given are rounded with the worksheet's rounding mode
+	ws, err = NewWorksheet([][]string{
+		{"Significant Figures", "yes"},
+		{"Rounding", "truncate 1dp"},
+		{"100", "fahrenheit", "celsius"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, Correct, ws.Decide(0, 30, "30"))
+	assert.Equal(t, Incorrect, ws.Decide(0, 40, "40"))
+}
+
+func TestGradeWorksheetSignificantFigures(t *testing.T) {
+	ws, _ := NewWorksheet([][]string{
+		{"Significant Figures", "on"},
+		{"ID", "Input", "From Unit", "To Unit"},
+		{"q1", "100.", "fahrenheit", "celsius"},
+		{"q2", "2.00", "liters", "cups"},
+	})
+	submissions, _ := NewSubmissionList([][]string{
+		{"Student", "q2", "q1"},
+		{"Doug Doenges", "8.45", "37.80"},
+	})
+
+	submissions[0].GradeWorksheet(ws)
+	assert.Equal(t, []Decision{WrongSigFigs, Correct}, submissions[0].Decisions)
+	assert.Equal(t, 1., submissions[0].EarnedPoints)
+	assert.Equal(t, []string{"37.80", "Wrong Sig Figs"}, submissions[0].ToGrid(0))
+}
@@ -3,12 +3,21 @@ package app
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 type Submission struct {
-	StudentName string
-	Responses   []*float64
-	Decisions   []Decision
+	StudentName  string
+	Responses    []*float64
+	RawResponses []string // responses as written, "" when blank
+	Decisions    []Decision
+
+	ResponseIDs  []string // question ID of each response, nil when matched by position
+	UnmatchedIDs []string // response IDs that are not on the worksheet
+
+	EarnedPoints   float64
+	PossiblePoints float64      // excludes invalid questions
+	Groups         []GroupScore // subtotals in worksheet order
 }
 
 type Decision string
@@ -17,16 +26,44 @@ const (
 	Correct   Decision = "Correct"
 	Incorrect Decision = "Incorrect"
 	Invalid   Decision = "Invalid"
+	// the right value with the wrong number of significant figures, earning no points
+	WrongSigFigs Decision = "Wrong Sig Figs"
 )
 
+// studentHeaders name the first column of an optional responses header row,
+// whose other columns label each response with its question ID.
+var studentHeaders = map[string]struct{}{
+	"student":      {},
+	"student name": {},
+	"name":         {},
+}
+
 func NewSubmissionList(data [][]string) ([]Submission, error) {
 	submissions := make([]Submission, 0)
 
+	var responseIDs []string
+	if len(data) > 0 && len(data[0]) > 0 {
+		if _, ok := studentHeaders[normalizeColumnName(data[0][0])]; ok {
+			responseIDs = make([]string, 0, len(data[0])-1)
+			seen := make(map[string]struct{}, len(data[0])-1)
+			for _, id := range data[0][1:] {
+				id = strings.TrimSpace(id)
+				if _, ok := seen[strings.ToLower(id)]; ok && id != "" {
+					return nil, fmt.Errorf("duplicate question ID '%s' in responses header", id)
+				}
+				seen[strings.ToLower(id)] = struct{}{}
+				responseIDs = append(responseIDs, id)
+			}
+			data = data[1:]
+		}
+	}
+
 	for _, row := range data {
 		submission, err := newSubmission(row)
 		if err != nil {
 			return nil, err
 		}
+		submission.ResponseIDs = responseIDs
 		submissions = append(submissions, submission)
 	}
 
@@ -39,6 +76,7 @@ func newSubmission(data []string) (Submission, error) {
 	resp.StudentName = data[0]
 
 	for _, val := range data[1:] {
+		resp.RawResponses = append(resp.RawResponses, val)
 		if val == "" {
 			resp.Responses = append(resp.Responses, nil)
 			continue
@@ -53,33 +91,115 @@ func newSubmission(data []string) (Submission, error) {
 	return resp, nil
 }
 
+// Grade marks each response correct when it rounds to the answer key.
 func (s *Submission) Grade(answerKey []*float64) {
+	s.grade(answerKey, func(idx int, response float64) Decision {
+		return decisionOf(*answerKey[idx] == roundFunc(response))
+	})
+	s.tally(func(int) float64 { return DefaultPoints })
+}
+
+// GradeWorksheet marks each response against the worksheet, applying
+// every question's tolerance.
+func (s *Submission) GradeWorksheet(ws Worksheet) {
+	s.matchQuestionIDs(ws)
+	s.grade(ws.Key(), func(idx int, response float64) Decision {
+		return ws.Decide(idx, response, s.rawResponse(idx))
+	})
+	s.tallyGroups(ws)
+}
+
+// matchQuestionIDs reorders responses into worksheet order by question ID.
+// Responses stay matched by position unless both the worksheet and the
+// responses have IDs. A question without an ID keeps the response in its
+// position, unless that response is labelled with an ID.
+func (s *Submission) matchQuestionIDs(ws Worksheet) {
+	if s.ResponseIDs == nil || !ws.HasQuestionIDs() {
+		return
+	}
+
+	byID := make(map[string]int, len(s.ResponseIDs))
+	for idx, id := range s.ResponseIDs {
+		if id != "" && idx < len(s.Responses) {
+			byID[strings.ToLower(id)] = idx
+		}
+	}
+
+	matched := make([]*float64, 0, len(ws.Questions))
+	matchedRaw := make([]string, 0, len(ws.Questions))
+	ids := make([]string, 0, len(ws.Questions))
+	for _, q := range ws.Questions {
+		key := strings.ToLower(q.ID)
+		idx, ok := byID[key]
+		if q.ID == "" {
+			idx = len(matched)
+			ok = idx < len(s.Responses) && (idx >= len(s.ResponseIDs) || s.ResponseIDs[idx] == "")
+		}
+		if ok {
+			matched = append(matched, s.Responses[idx])
+			matchedRaw = append(matchedRaw, s.rawResponse(idx))
+		} else {
+			matched = append(matched, nil)
+			matchedRaw = append(matchedRaw, "")
+		}
+		ids = append(ids, q.ID)
+		delete(byID, key)
+	}
+
+	s.UnmatchedIDs = nil
+	for _, id := range s.ResponseIDs {
+		if _, ok := byID[strings.ToLower(id)]; ok {
+			s.UnmatchedIDs = append(s.UnmatchedIDs, id)
+		}
+	}
+	s.Responses, s.RawResponses, s.ResponseIDs = matched, matchedRaw, ids
+}
+
+// rawResponse is a response as written, or "" when there is none.
+func (s *Submission) rawResponse(idx int) string {
+	if idx >= len(s.RawResponses) {
+		return ""
+	}
+	return s.RawResponses[idx]
+}
+
+func (s *Submission) grade(answerKey []*float64, decide func(idx int, response float64) Decision) {
+	s.Decisions = make([]Decision, 0, len(answerKey))
 	for idx := range answerKey {
 		var decision Decision
-		if answerKey[idx] == nil {
+		switch {
+		case answerKey[idx] == nil:
 			decision = Invalid
-		} else {
-			// question and response mismatch
-			if idx >= len(s.Responses) {
-				decision = Incorrect
-				continue
-			}
-
-			correct := *answerKey[idx] == roundFunc(*s.Responses[idx])
-			if correct {
-				decision = Correct
-			} else {
-				decision = Incorrect
-			}
+		// question and response mismatch, or no response given
+		case idx >= len(s.Responses) || s.Responses[idx] == nil:
+			decision = Incorrect
+		default:
+			decision = decide(idx, *s.Responses[idx])
 		}
 		s.Decisions = append(s.Decisions, decision)
 	}
 }
 
+func (s *Submission) tally(points func(idx int) float64) {
+	s.EarnedPoints, s.PossiblePoints = 0, 0
+	for idx, decision := range s.Decisions {
+		if decision == Invalid {
+			continue
+		}
+		s.PossiblePoints += points(idx)
+		if decision == Correct {
+			s.EarnedPoints += points(idx)
+		}
+	}
+}
+
 func (s *Submission) ToGrid(questionIdx int) []string {
 	responseStr := ""
 	if len(s.Responses) > questionIdx && s.Responses[questionIdx] != nil {
-		responseStr = strconv.FormatFloat(*s.Responses[questionIdx], 'f', -1, 64)
+		responseStr = strings.TrimSpace(s.rawResponse(questionIdx))
+		if responseStr == "" {
+			responseStr = strconv.FormatFloat(*s.Responses[questionIdx], 'f', -1, 64)
+		}
 	}
 	decision := ""
 	if len(s.Decisions) > questionIdx {
@@ -87,3 +207,28 @@ func (s *Submission) ToGrid(questionIdx int) []string {
 	}
 	return []string{responseStr, decision}
 }
+
+// ScoreToGrid shows the student's points and percentage beneath their responses.
+func (s *Submission) ScoreToGrid() []string {
+	return scoreToGrid(s.EarnedPoints, s.PossiblePoints)
+}
+
+// GroupScoreToGrid shows the student's subtotal of a group like ScoreToGrid.
+func (s *Submission) GroupScoreToGrid(groupIdx int) []string {
+	if groupIdx >= len(s.Groups) {
+		return []string{"", ""}
+	}
+	return scoreToGrid(s.Groups[groupIdx].EarnedPoints, s.Groups[groupIdx].PossiblePoints)
+}
+
+// scoreToGrid shows points earned of those possible and their percentage.
+func scoreToGrid(earned, possible float64) []string {
+	percentage := 0.
+	if possible != 0 {
+		percentage = earned / possible * 100
+	}
+	return []string{
+		strconv.FormatFloat(earned, 'f', -1, 64) + "/" + strconv.FormatFloat(possible, 'f', -1, 64),
+		strconv.FormatFloat(roundTo(percentage, 1), 'f', -1, 64) + "%",
+	}
+}
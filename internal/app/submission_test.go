@@ -61,3 +61,119 @@ func TestSubmissionToGrid(t *testing.T) {
 	assert.Len(t, gridS, 2)
 	assert.Equal(t, gridS[0], "")
 }
+
+func TestGradeWorksheet(t *testing.T) {
+	ws, _ := NewWorksheet([][]string{
+		{"Input", "From Unit", "To Unit", "Tolerance"},
+		{"100", "liters", "gallons", "0.1"},
+		{"100", "liters", "gallons", ""},
+		{"100", "liters", "gallons", ""},
+	})
+	submissions, _ := NewSubmissionList([][]string{
+		{"Test Name", "26.34", "26.34", ""},
+		{"Short Name", "26.4"},
+	})
+
+	submissions[0].GradeWorksheet(ws)
+	assert.Equal(t, []Decision{Correct, Incorrect, Incorrect}, submissions[0].Decisions)
+
+	submissions[1].GradeWorksheet(ws)
+	assert.Equal(t, []Decision{Correct, Incorrect, Incorrect}, submissions[1].Decisions)
+}
+
+func TestWeightedScore(t *testing.T) {
+	ws, _ := NewWorksheet([][]string{
+		{"Input", "From Unit", "To Unit", "Points"},
+		{"100", "liters", "gallons", "3"},
+		{"100", "kelvin", "celsius", ""},
+		{"100", "not a unit", "celsius", "5"},
+	})
+	submissions, _ := NewSubmissionList([][]string{
+		{"Test Name", "26.4", "0", "1"},
+	})
+
+	submissions[0].GradeWorksheet(ws)
+	assert.Equal(t, 3., submissions[0].EarnedPoints)
+	assert.Equal(t, 4., submissions[0].PossiblePoints)
+	assert.Equal(t, []string{"3/4", "75%"}, submissions[0].ScoreToGrid())
+
+	assert.Equal(t, []string{"0/0", "0%"}, (&Submission{}).ScoreToGrid())
+}
+
+func TestGradeByQuestionID(t *testing.T) {
+	ws, err := NewWorksheet([][]string{
+		{"ID", "Input", "From Unit", "To Unit"},
+		{"q1", "100", "liters", "gallons"},
+		{"q2", "100", "kelvin", "celsius"},
+		{"q3", "1", "gallons", "cups"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, ws.HasQuestionIDs())
+
+	submissions, err := NewSubmissionList([][]string{
+		{"Student Name", "Q3", "q1", "q9"},
+		{"Test Name", "16", "26.4", "5"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Q3", "q1", "q9"}, submissions[0].ResponseIDs)
+
+	submissions[0].GradeWorksheet(ws)
+	assert.Equal(t, []Decision{Correct, Incorrect, Correct}, submissions[0].Decisions)
+	assert.Equal(t, []string{"q9"}, submissions[0].UnmatchedIDs)
+	assert.Equal(t, []string{"26.4", "Correct"}, submissions[0].ToGrid(0))
+	assert.Equal(t, []string{"", "Incorrect"}, submissions[0].ToGrid(1))
+
+	// without IDs on the worksheet responses keep their positions
+	positional, _ := NewWorksheet([][]string{
+		{"1", "gallons", "cups"},
+		{"100", "liters", "gallons"},
+	})
+	submissions, _ = NewSubmissionList([][]string{
+		{"Name", "q3", "q1"},
+		{"Test Name", "16", "26.4"},
+	})
+	submissions[0].GradeWorksheet(positional)
+	assert.Equal(t, []Decision{Correct, Correct}, submissions[0].Decisions)
+
+	_, err = NewWorksheet([][]string{
+		{"Question ID", "Input", "From Unit", "To Unit"},
+		{"q1", "100", "liters", "gallons"},
+		{"Q1", "100", "kelvin", "celsius"},
+	})
+	assert.Error(t, err)
+}
+
+func TestGradeMixedQuestionIDs(t *testing.T) {
+	ws, err := NewWorksheet([][]string{
+		{"ID", "Input", "From Unit", "To Unit"},
+		{"q1", "100", "liters", "gallons"},
+		{"", "100", "kelvin", "celsius"},
+		{"", "1", "gallons", "cups"},
+	})
+	assert.NoError(t, err)
+
+	// questions without an ID take the unlabelled response in their position
+	submissions, err := NewSubmissionList([][]string{
+		{"Student Name", "q1", "", "q3"},
+		{"Test Name", "26.4", "-173.1", "16"},
+	})
+	assert.NoError(t, err)
+	submissions[0].GradeWorksheet(ws)
+	assert.Equal(t, []Decision{Correct, Correct, Incorrect}, submissions[0].Decisions)
+	assert.Equal(t, []string{"q3"}, submissions[0].UnmatchedIDs)
+}
+
+func TestDuplicateResponseIDs(t *testing.T) {
+	_, err := NewSubmissionList([][]string{
+		{"Student Name", "q1", "Q1"},
+		{"Test Name", "26.4", "5"},
+	})
+	assert.EqualError(t, err, "duplicate question ID 'Q1' in responses header")
+
+	// unlabelled responses are not duplicates
+	_, err = NewSubmissionList([][]string{
+		{"Student Name", "", ""},
+		{"Test Name", "26.4", "5"},
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// placeholder matches a template placeholder such as {rand 50..150 step 5}
+// or {choose 1,2,5,10}.
+var placeholder = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// ExpandTemplate replaces every placeholder of a worksheet template with a
+// value drawn with the seed, producing worksheet rows that NewWorksheet
+// reads. The same template and seed always produce the same worksheet.
+//
+//	{rand 50..150}         a whole number from 50 to 150
+//	{rand 0.5..2 step 0.5} a multiple of the step from the range
+//	{choose 1,2,5,10}      one of the listed values
+func ExpandTemplate(data [][]string, seed int64) ([][]string, error) {
+	rng := rand.New(rand.NewSource(seed))
+	expanded := make([][]string, 0, len(data))
+	for rowIdx, row := range data {
+		row, err := joinSplitPlaceholders(row)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %v", rowIdx+1, err)
+		}
+		expandedRow := make([]string, 0, len(row))
+		for _, cell := range row {
+			var err error
+			cell = placeholder.ReplaceAllStringFunc(cell, func(match string) string {
+				value, expandErr := expandPlaceholder(strings.TrimSpace(match[1:len(match)-1]), rng)
+				if expandErr != nil && err == nil {
+					err = fmt.Errorf("row %d: %v in '%s'", rowIdx+1, expandErr, match)
+				}
+				return value
+			})
+			if err != nil {
+				return nil, err
+			}
+			expandedRow = append(expandedRow, cell)
+		}
+		expanded = append(expanded, expandedRow)
+	}
+	return expanded, nil
+}
+
+// joinSplitPlaceholders rejoins the cells of a placeholder that an unquoted
+// CSV file split at its commas, e.g. "{choose 1", "2}" back into "{choose 1,2}".
+func joinSplitPlaceholders(row []string) ([]string, error) {
+	joined := make([]string, 0, len(row))
+	open := false
+	for _, cell := range row {
+		if open {
+			joined[len(joined)-1] += "," + cell
+		} else {
+			joined = append(joined, cell)
+		}
+		open = strings.LastIndex(joined[len(joined)-1], "{") > strings.LastIndex(joined[len(joined)-1], "}")
+	}
+	if open {
+		return nil, fmt.Errorf("unclosed placeholder in '%s'", joined[len(joined)-1])
+	}
+	return joined, nil
+}
+
+func expandPlaceholder(expr string, rng *rand.Rand) (string, error) {
+	function, args, _ := strings.Cut(expr, " ")
+	args = strings.TrimSpace(args)
+	switch strings.ToLower(function) {
+	case "rand":
+		return expandRand(args, rng)
+	case "choose":
+		choices := strings.Split(args, ",")
+		for idx := range choices {
+			choices[idx] = strings.TrimSpace(choices[idx])
+		}
+		if args == "" {
+			return "", fmt.Errorf("nothing to choose from")
+		}
+		if slices.Contains(choices, "") {
+			return "", fmt.Errorf("empty choice")
+		}
+		return choices[rng.Intn(len(choices))], nil
+	default:
+		return "", fmt.Errorf("unknown placeholder '%s': use rand or choose", function)
+	}
+}
+
+// expandRand draws from "MIN..MAX" or "MIN..MAX step STEP", written with as
+// many decimal places as the most precise of the three.
+func expandRand(args string, rng *rand.Rand) (string, error) {
+	rangeStr, stepStr, hasStep := strings.Cut(strings.ToLower(args), "step")
+	minStr, maxStr, found := strings.Cut(strings.TrimSpace(rangeStr), "..")
+	if !found {
+		return "", fmt.Errorf("invalid range: give MIN..MAX")
+	}
+	minStr, maxStr, stepStr = strings.TrimSpace(minStr), strings.TrimSpace(maxStr), strings.TrimSpace(stepStr)
+	if !hasStep {
+		stepStr = "1"
+	}
+
+	minValue, minErr := strconv.ParseFloat(minStr, 64)
+	maxValue, maxErr := strconv.ParseFloat(maxStr, 64)
+	step, stepErr := strconv.ParseFloat(stepStr, 64)
+	switch {
+	case minErr != nil || maxErr != nil:
+		return "", fmt.Errorf("invalid range: give MIN..MAX")
+	case minValue > maxValue:
+		return "", fmt.Errorf("invalid range: %s is larger than %s", minStr, maxStr)
+	case stepErr != nil || step <= 0:
+		return "", fmt.Errorf("invalid step '%s'", stepStr)
+	}
+
+	decimals := max(decimalPlaces(minStr), decimalPlaces(maxStr), decimalPlaces(stepStr))
+	steps := int(math.Floor((maxValue-minValue)/step + floatSlack(maxValue)))
+	value := minValue + float64(rng.Intn(steps+1))*step
+	return strconv.FormatFloat(roundTo(value, decimals), 'f', decimals, 64), nil
+}
+
+func decimalPlaces(number string) int {
+	_, fraction, _ := strings.Cut(number, ".")
+	return len(fraction)
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandTemplate(t *testing.T) {
+	template := [][]string{
+		{"Title", "Term Quiz"},
+		{"Input", "From Unit", "To Unit"},
+		{"{rand 50..150 step 5}", "fahrenheit", "celsius"},
+		{"{choose 1,2,5,10}", "gallons", "{choose liters, quarts}"},
+		{"{rand 0.5..2 step 0.25}", "cups", "liters"},
+	}
+
+	rows, err := ExpandTemplate(template, 42)
+	assert.NoError(t, err)
+	assert.Equal(t, template[:2], rows[:2])
+
+	again, _ := ExpandTemplate(template, 42)
+	assert.Equal(t, rows, again)
+
+	for seed := range int64(50) {
+		rows, err := ExpandTemplate(template, seed)
+		assert.NoError(t, err)
+
+		fahrenheit, err := strconv.Atoi(rows[2][0])
+		assert.NoError(t, err)
+		assert.True(t, fahrenheit >= 50 && fahrenheit <= 150 && fahrenheit%5 == 0, rows[2][0])
+		assert.Contains(t, []string{"1", "2", "5", "10"}, rows[3][0])
+		assert.Contains(t, []string{"liters", "quarts"}, rows[3][2])
+		assert.Contains(t, []string{"0.50", "0.75", "1.00", "1.25", "1.50", "1.75", "2.00"}, rows[4][0])
+
+		ws, err := NewWorksheet(rows)
+		assert.NoError(t, err)
+		assert.Len(t, ws.Questions, 3)
+	}
+}
+
+func TestExpandTemplateText(t *testing.T) {
+	rows, err := ExpandTemplate([][]string{{"2", "liters", "", "{choose 0.5,1} gallons;{rand 3..3} cups"}}, 1)
+	assert.NoError(t, err)
+	assert.Contains(t, []string{"0.5 gallons;3 cups", "1 gallons;3 cups"}, rows[0][3])
+
+	// an unquoted CSV file splits a placeholder at its commas
+	rows, err = ExpandTemplate([][]string{{"{choose 1", "2", "5}", "gallons", "liters"}}, 1)
+	assert.NoError(t, err)
+	assert.Len(t, rows[0], 3)
+	assert.Contains(t, []string{"1", "2", "5"}, rows[0][0])
+
+	// cells without placeholders are kept as they are
+	rows, err = ExpandTemplate([][]string{{"100", "Fahrenheit", "Celsius"}}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"100", "Fahrenheit", "Celsius"}}, rows)
+}
+
+func TestExpandTemplateErrors(t *testing.T) {
+	for _, cell := range []string{
+		"{random 1..2}",
+		"{rand 1-2}",
+		"{rand 5..1}",
+		"{rand 1..a}",
+		"{rand 1..5 step 0}",
+		"{rand 1..5 step -1}",
+		"{choose}",
+		"{choose 1,,2}",
+		"{choose 1",
+	} {
+		_, err := ExpandTemplate([][]string{{"Input", "From Unit", "To Unit"}, {cell, "cups", "liters"}}, 1)
+		assert.ErrorContains(t, err, "row 2", cell)
+	}
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type ToleranceMode string
+
+const (
+	Exact         ToleranceMode = ""
+	Absolute      ToleranceMode = "absolute"
+	Percent       ToleranceMode = "percent"
+	DecimalPlaces ToleranceMode = "decimal places"
+)
+
+// Tolerance decides how close a response must be to the exact answer.
+// The zero value requires the rounded response to equal the key.
+type Tolerance struct {
+	Mode  ToleranceMode
+	Value float64
+}
+
+// ParseTolerance reads "0.5" as an absolute tolerance, "2%" as a percentage of
+// the answer and "2dp" as the number of decimal places that must match.
+func ParseTolerance(input string) (Tolerance, error) {
+	s := strings.TrimSpace(strings.ToLower(input))
+	if s == "" {
+		return Tolerance{}, nil
+	}
+
+	mode := Absolute
+	switch {
+	case strings.HasSuffix(s, "%"):
+		mode = Percent
+		s = strings.TrimSuffix(s, "%")
+	case strings.HasSuffix(s, "dp"):
+		mode = DecimalPlaces
+		s = strings.TrimSuffix(s, "dp")
+	}
+
+	val, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || val < 0 {
+		return Tolerance{}, fmt.Errorf("invalid tolerance '%s'", input)
+	}
+	if mode == DecimalPlaces && val != math.Trunc(val) {
+		return Tolerance{}, fmt.Errorf("invalid tolerance '%s': decimal places must be a whole number", input)
+	}
+
+	return Tolerance{mode, val}, nil
+}
+
+func (t Tolerance) String() string {
+	valStr := strconv.FormatFloat(t.Value, 'f', -1, 64)
+	switch t.Mode {
+	case Absolute:
+		return valStr
+	case Percent:
+		return valStr + "%"
+	case DecimalPlaces:
+		return valStr + "dp"
+	default:
+		return ""
+	}
+}
+
+// Accepts reports whether response is close enough to a question's exact
+// answer, or to its rounded key when no tolerance is given. Responses and
+// decimal places are rounded with rounding's mode, as the key was.
+func (t Tolerance) Accepts(key, exact, response float64, rounding Rounding) bool {
+	switch t.Mode {
+	case Absolute:
+		return math.Abs(response-exact) <= t.Value+floatSlack(exact)
+	case Percent:
+		return math.Abs(response-exact) <= math.Abs(exact)*t.Value/100+floatSlack(exact)
+	case DecimalPlaces:
+		places := Rounding{rounding.Mode, Places, int(t.Value)}
+		return places.Round(response) == places.Round(exact)
+	default:
+		return key == rounding.Round(response)
+	}
+}
+
+// floatSlack absorbs floating point error so boundary responses are accepted.
+func floatSlack(val float64) float64 {
+	return 1e-9 * math.Max(1, math.Abs(val))
+}
+
+func roundTo(value float64, places int) float64 {
+	pow := math.Pow(10, float64(places))
+	return math.Floor(value*pow+0.5) / pow
+}
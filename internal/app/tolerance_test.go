@@ -0,0 +1,59 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTolerance(t *testing.T) {
+	tolerancesToTest := map[string]Tolerance{
+		"":       {},
+		"0.5":    {Absolute, 0.5},
+		" 2% ":   {Percent, 2},
+		"2dp":    {DecimalPlaces, 2},
+		"0 dp":   {DecimalPlaces, 0},
+		"1.25 %": {Percent, 1.25},
+	}
+	for s, expected := range tolerancesToTest {
+		tolerance, err := ParseTolerance(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, tolerance, s)
+	}
+
+	for _, s := range []string{"abc", "-1", "1.5dp", "%"} {
+		_, err := ParseTolerance(s)
+		assert.Error(t, err, s)
+	}
+
+	_, err := ParseTolerance("1.5dp")
+	assert.EqualError(t, err, "invalid tolerance '1.5dp': decimal places must be a whole number")
+	_, err = ParseTolerance("-2%")
+	assert.EqualError(t, err, "invalid tolerance '-2%'")
+}
+
+func TestToleranceAccepts(t *testing.T) {
+	// 100 liters is 26.417205 gallons
+	exact := 26.417205
+	key := roundFunc(exact)
+
+	assert.True(t, Tolerance{}.Accepts(key, exact, 26.41, DefaultRounding))
+	assert.False(t, Tolerance{}.Accepts(key, exact, 26.34, DefaultRounding))
+
+	assert.True(t, Tolerance{Absolute, 0.1}.Accepts(key, exact, 26.34, DefaultRounding))
+	assert.False(t, Tolerance{Absolute, 0.05}.Accepts(key, exact, 26.34, DefaultRounding))
+
+	assert.True(t, Tolerance{Percent, 1}.Accepts(key, exact, 26.2, DefaultRounding))
+	assert.False(t, Tolerance{Percent, 1}.Accepts(key, exact, 26.1, DefaultRounding))
+
+	assert.True(t, Tolerance{DecimalPlaces, 2}.Accepts(key, exact, 26.42, DefaultRounding))
+	assert.False(t, Tolerance{DecimalPlaces, 2}.Accepts(key, exact, 26.4, DefaultRounding))
+	assert.True(t, Tolerance{DecimalPlaces, 0}.Accepts(key, exact, 26, DefaultRounding))
+
+	// decimal places are compared with the worksheet's rounding mode
+	truncate := Rounding{Truncate, Places, 1}
+	assert.True(t, Tolerance{DecimalPlaces, 2}.Accepts(key, exact, 26.414, truncate))
+	assert.False(t, Tolerance{DecimalPlaces, 2}.Accepts(key, exact, 26.414, DefaultRounding))
+	assert.False(t, Tolerance{DecimalPlaces, 0}.Accepts(key, exact, 26.5, DefaultRounding))
+	assert.True(t, Tolerance{DecimalPlaces, 0}.Accepts(key, 26.5, 26.5, Rounding{HalfEven, Places, 1}))
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// uncertaintySeparators split a measured value from its uncertainty, e.g. "100 ± 0.5".
+var uncertaintySeparators = []string{"±", "+/-", "+-"}
+
+// splitUncertainty splits an input such as "100 ± 0.5" into the value as
+// written and its uncertainty, which is nil when the input has none.
+func splitUncertainty(s string) (string, *float64, error) {
+	for _, separator := range uncertaintySeparators {
+		valStr, uncertaintyStr, found := strings.Cut(s, separator)
+		if !found {
+			continue
+		}
+		uncertainty, err := strconv.ParseFloat(strings.TrimSpace(uncertaintyStr), 64)
+		if err != nil || uncertainty < 0 {
+			return "", nil, fmt.Errorf("invalid uncertainty '%s': give a value such as 100 ± 0.5", strings.TrimSpace(uncertaintyStr))
+		}
+		return strings.TrimSpace(valStr), &uncertainty, nil
+	}
+	return s, nil, nil
+}
+
+// propagateUncertainty converts the input's uncertainty to the answer's unit
+// as half the width of the interval the input's interval converts to.
+// Choice questions have no uncertain answer.
+func propagateUncertainty(q Question, conv Converter, round func(float64) float64) *float64 {
+	if q.Uncertainty == nil || q.Type.isChoice() {
+		return nil
+	}
+	low, high := q, q
+	low.Input, high.Input = q.Input-*q.Uncertainty, q.Input+*q.Uncertainty
+	lowAnswer, lowErr := answerQuestion(low, conv, round)
+	highAnswer, highErr := answerQuestion(high, conv, round)
+	if lowErr != nil || highErr != nil {
+		return nil
+	}
+	uncertainty := math.Abs(highAnswer-lowAnswer) / 2
+	return &uncertainty
+}
+
+// withinUncertainty reports whether a response lies in the interval of the
+// question's exact answer give or take its propagated uncertainty.
+func (q Question) withinUncertainty(response float64) bool {
+	if q.AnswerUncertainty == nil {
+		return false
+	}
+	return math.Abs(response-q.exact) <= *q.AnswerUncertainty+floatSlack(q.exact)
+}
+
+// formatUncertainty writes an uncertainty to two significant figures, as
+// uncertainties are usually quoted.
+func formatUncertainty(uncertainty float64) string {
+	rounded := Rounding{HalfUp, SignificantFigures, 2}.Round(uncertainty)
+	return "± " + strconv.FormatFloat(rounded, 'f', -1, 64)
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitUncertainty(t *testing.T) {
+	valStr, uncertainty, err := splitUncertainty("100 ± 0.5")
+	assert.NoError(t, err)
+	assert.Equal(t, "100", valStr)
+	assert.Equal(t, 0.5, *uncertainty)
+
+	valStr, uncertainty, err = splitUncertainty("2.00+/-0.05")
+	assert.NoError(t, err)
+	assert.Equal(t, "2.00", valStr)
+	assert.Equal(t, 0.05, *uncertainty)
+
+	valStr, uncertainty, err = splitUncertainty("100")
+	assert.NoError(t, err)
+	assert.Equal(t, "100", valStr)
+	assert.Nil(t, uncertainty)
+
+	_, _, err = splitUncertainty("100 ± -1")
+	assert.EqualError(t, err, "invalid uncertainty '-1': give a value such as 100 ± 0.5")
+	_, _, err = splitUncertainty("100 ±")
+	assert.Error(t, err)
+}
+
+func TestUncertaintyWorksheet(t *testing.T) {
+	data := [][]string{
+		{"Input", "From Unit", "To Unit", "Type"},
+		{"100 ± 0.5", "celsius", "fahrenheit", ""},
+		{"2 +/- 0.1", "liters", "cups > tablespoons", ""},
+		{"100.0", "fahrenheit", "rankine", ""},
+		{"212 ± 1", "celsius", "fahrenheit", "reverse"},
+	}
+	ws, err := NewWorksheet(data)
+	assert.NoError(t, err)
+
+	q := ws.Questions[0]
+	assert.Equal(t, "100", q.InputText)
+	assert.Equal(t, 0.5, *q.Uncertainty)
+	assert.InDelta(t, 0.9, *q.AnswerUncertainty, 1e-9)
+	assert.Equal(t, []string{"100 ± 0.5", "celsius", "fahrenheit", "212 ± 0.9"}, q.ToGrid())
+
+	// each step of a chain carries the uncertainty of the one before
+	assert.InDelta(t, 0.42268, *ws.Questions[1].AnswerUncertainty, 1e-5)
+	assert.InDelta(t, 6.7628, *ws.Questions[2].AnswerUncertainty, 1e-4)
+	assert.Equal(t, "8.5 ± 0.42", ws.Questions[2].ToGrid()[0])
+	assert.Nil(t, ws.Questions[3].AnswerUncertainty)
+	assert.InDelta(t, 5./9, *ws.Questions[4].AnswerUncertainty, 1e-9)
+
+	// within the interval only counts when the worksheet accepts uncertainty
+	assert.False(t, ws.Accepts(0, 212.8))
+	ws.Metadata.AcceptUncertainty = true
+	assert.True(t, ws.Accepts(0, 212.8))
+	assert.True(t, ws.Accepts(0, 211.1))
+	assert.False(t, ws.Accepts(0, 213))
+	assert.True(t, ws.Accepts(3, 559.7))
+
+	data = append([][]string{{"Accept Uncertainty", "yes"}}, data...)
+	ws, err = NewWorksheet(data)
+	assert.NoError(t, err)
+	assert.True(t, ws.Metadata.AcceptUncertainty)
+	assert.Equal(t, []string{"Accept Uncertainty", "yes"}, ws.Metadata.ToGrid()[0])
+
+	_, err = NewWorksheet([][]string{{"100 ± x", "celsius", "fahrenheit"}})
+	assert.EqualError(t, err, "invalid uncertainty 'x': give a value such as 100 ± 0.5 in question: 100 ± x,celsius,fahrenheit")
+}
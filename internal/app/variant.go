@@ -0,0 +1,87 @@
+package app
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// StudentSeed derives the seed of a student's worksheet variant from the
+// shared seed and the student's name or ID, ignoring case and spacing.
+func StudentSeed(seed int64, student string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(strings.Join(strings.Fields(strings.ToLower(student)), " ")))
+	return seed ^ int64(h.Sum64())
+}
+
+// GenerateVariant produces the worksheet variant of a single student.
+func GenerateVariant(opts GeneratorOptions, student string) ([][]string, error) {
+	opts.Seed = StudentSeed(opts.Seed, student)
+	return GenerateWorksheet(opts)
+}
+
+// ExpandTemplateVariant expands a worksheet template for a single student.
+func ExpandTemplateVariant(template [][]string, seed int64, student string) ([][]string, error) {
+	return ExpandTemplate(template, StudentSeed(seed, student))
+}
+
+// NewRoster reads student names or IDs from the first column, skipping blanks.
+func NewRoster(data [][]string) ([]string, error) {
+	roster := make([]string, 0, len(data))
+	for _, row := range data {
+		if len(row) == 0 || strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		roster = append(roster, strings.TrimSpace(row[0]))
+	}
+	if len(roster) == 0 {
+		return nil, fmt.Errorf("no students found")
+	}
+	return roster, nil
+}
+
+// GetVariantResults grades every submission against its own worksheet variant.
+// variants[i] is the worksheet of submissions[i].
+func GetVariantResults(variants []Worksheet, submissions []Submission) (Results, error) {
+	if len(variants) != len(submissions) {
+		return Results{}, fmt.Errorf("%d worksheet variants given for %d submissions", len(variants), len(submissions))
+	}
+	for idx := range submissions {
+		submissions[idx].GradeWorksheet(variants[idx])
+	}
+	return Results{
+		variants:          variants,
+		gradedSubmissions: submissions,
+	}, nil
+}
+
+// variantGridDisplay lists each student's own questions one row per question,
+// since students no longer share the question columns.
+func (r *Results) variantGridDisplay() [][]string {
+	gridDisplay := make([][]string, 0)
+	gridDisplay = appendMetadata(gridDisplay, r.variants[0].Metadata)
+	gridDisplay = append(gridDisplay,
+		[]string{"Student", "Input", "From Unit", "To Unit", "Correct Answer", "Response", "Decision"})
+
+	for idx, submission := range r.gradedSubmissions {
+		ws := r.variants[idx]
+		for qIdx := range ws.Questions {
+			row := []string{submission.StudentName}
+			row = append(row, ws.Questions[qIdx].ToGrid()...)
+			row = append(row, submission.ToGrid(qIdx)...)
+			gridDisplay = append(gridDisplay, row)
+		}
+		if ws.HasGroups() {
+			for groupIdx, group := range ws.Groups {
+				row := []string{submission.StudentName, "", "", "", groupLabel(group.Name)}
+				row = append(row, submission.GroupScoreToGrid(groupIdx)...)
+				gridDisplay = append(gridDisplay, row)
+			}
+		}
+		row := []string{submission.StudentName, "", "", "", "Score"}
+		row = append(row, submission.ScoreToGrid()...)
+		gridDisplay = append(gridDisplay, row)
+	}
+
+	return gridDisplay
+}
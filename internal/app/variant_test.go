@@ -0,0 +1,57 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStudentSeed(t *testing.T) {
+	assert.Equal(t, StudentSeed(1, "Doug Doenges"), StudentSeed(1, " doug  DOENGES "))
+	assert.NotEqual(t, StudentSeed(1, "Doug Doenges"), StudentSeed(1, "Student B"))
+	assert.NotEqual(t, StudentSeed(1, "Doug Doenges"), StudentSeed(2, "Doug Doenges"))
+}
+
+func TestGenerateVariant(t *testing.T) {
+	opts := GeneratorOptions{Count: 5, Max: 100, Seed: 42}
+
+	a, err := GenerateVariant(opts, "Student A")
+	assert.NoError(t, err)
+	again, _ := GenerateVariant(opts, "Student A")
+	assert.Equal(t, a, again)
+
+	b, _ := GenerateVariant(opts, "Student B")
+	assert.NotEqual(t, a, b)
+}
+
+func TestNewRoster(t *testing.T) {
+	roster, err := NewRoster([][]string{{"Student A", "1", "2"}, {""}, {}, {" Student B "}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Student A", "Student B"}, roster)
+
+	_, err = NewRoster([][]string{{""}})
+	assert.Error(t, err)
+}
+
+func TestGetVariantResults(t *testing.T) {
+	wsA, _ := NewWorksheet([][]string{{"100", "liters", "gallons"}})
+	wsB, _ := NewWorksheet([][]string{{"100", "kelvin", "celsius"}})
+	submissions, _ := NewSubmissionList([][]string{
+		{"Student A", "26.4"},
+		{"Student B", "26.4"},
+	})
+
+	_, err := GetVariantResults([]Worksheet{wsA}, submissions)
+	assert.Error(t, err)
+
+	res, err := GetVariantResults([]Worksheet{wsA, wsB}, submissions)
+	assert.NoError(t, err)
+	testDisplay := [][]string{
+		{"Student", "Input", "From Unit", "To Unit", "Correct Answer", "Response", "Decision"},
+		{"Student A", "100", "liters", "gallons", "26.4", "26.4", "Correct"},
+		{"Student A", "", "", "", "Score", "1/1", "100%"},
+		{"Student B", "100", "kelvin", "celsius", "-173.1", "26.4", "Incorrect"},
+		{"Student B", "", "", "", "Score", "0/1", "0%"},
+	}
+	assert.Equal(t, testDisplay, res.ToGridDisplay())
+}
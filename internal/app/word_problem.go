@@ -0,0 +1,263 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// WordProblem is a conversion asked in plain English, such as
+// "Convert 100°F to Rankine" or "How many cups are in 3 liters?".
+type WordProblem struct {
+	Input       string // value as written
+	FromUnit    string
+	ToUnit      string
+	Uncertainty string // uncertainty of the value as written, "" when not given
+}
+
+// unitAbbreviations are the ways units are written in sentences, other than
+// their names and the generated singular, British and "degrees" forms.
+var unitAbbreviations = map[string]string{
+	"°f": "fahrenheit", "f": "fahrenheit", "deg f": "fahrenheit", "degrees f": "fahrenheit",
+	"°c": "celsius", "c": "celsius", "deg c": "celsius", "degrees c": "celsius",
+	"centigrade": "celsius", "degrees centigrade": "celsius",
+	"k": "kelvin", "kelvins": "kelvin",
+	"°r": "rankine", "°ra": "rankine",
+	"°re": "reaumur", "°ré": "reaumur", "°de": "delisle", "°n": "newton", "°rø": "romer",
+
+	"l": "liters", "ml": "milliliters", "cc": "cubic centimeters",
+	"cm³": "cubic centimeters", "cm3": "cubic centimeters", "m³": "cubic meters", "m3": "cubic meters",
+	"in³": "cubic inches", "in3": "cubic inches", "ft³": "cubic feet", "ft3": "cubic feet",
+	"yd³": "cubic yards", "yd3": "cubic yards", "cubic foot": "cubic feet",
+	"gal": "gallons", "qt": "quarts", "pt": "pints", "fl oz": "fluid ounces", "floz": "fluid ounces",
+	"tbsp": "tablespoons", "tbs": "tablespoons", "tsp": "teaspoons", "bbl": "barrels",
+
+	"sec": "seconds", "secs": "seconds", "min": "minutes", "mins": "minutes",
+	"hr": "hours", "hrs": "hours",
+}
+
+// unitPhrases maps every way of writing a unit to its name.
+var unitPhrases = make(map[string]string)
+
+// longestUnitPhrase is the most words a unit phrase has.
+var longestUnitPhrase int
+
+func init() {
+	add := func(phrase, unit string) {
+		unitPhrases[phrase] = unit
+		longestUnitPhrase = max(longestUnitPhrase, len(strings.Fields(phrase)))
+	}
+	for dimension, scales := range unitConversions {
+		if dimension == "flow rate" {
+			continue
+		}
+		for unit := range scales {
+			add(unit, unit)
+			if dimension == "temperature" {
+				add("degrees "+unit, unit)
+				add("degree "+unit, unit)
+				continue
+			}
+			singular := strings.TrimSuffix(unit, "s")
+			if strings.HasSuffix(unit, "ches") {
+				singular = strings.TrimSuffix(unit, "es")
+			}
+			for _, form := range []string{unit, singular} {
+				add(form, unit)
+				add(strings.NewReplacer("liter", "litre", "meter", "metre").Replace(form), unit)
+			}
+		}
+	}
+	// the aliases are all temperatures
+	for alias, unit := range unitAliases {
+		add(alias, unit)
+		add("degrees "+alias, unit)
+	}
+	for phrase, unit := range unitAbbreviations {
+		add(phrase, unit)
+	}
+}
+
+// leadingNumber matches a number at the start of a word, e.g. "100" of "100°f".
+var leadingNumber = regexp.MustCompile(`^[-+]?(\d+\.?\d*|\.\d+)(e[-+]?\d+)?`)
+
+type wordToken struct {
+	number    string // set for a number
+	unit      string // set for a unit
+	per       bool   // the word "per", joining a flow rate
+	plusMinus bool   // "±", between a value and its uncertainty
+}
+
+// ParseWordProblem extracts the value, source unit and target unit of a
+// conversion question: the value is the first number, optionally followed
+// by its uncertainty ("100 ± 0.5"), the source unit follows it and the
+// target unit is the other unit mentioned.
+func ParseWordProblem(text string) (WordProblem, error) {
+	tokens := composeRates(tokenizeWords(text))
+
+	valueIdx := -1
+	for idx, token := range tokens {
+		if token.number != "" {
+			valueIdx = idx
+			break
+		}
+	}
+	if valueIdx == -1 {
+		return WordProblem{}, fmt.Errorf("no value found in '%s'", text)
+	}
+	problem := WordProblem{Input: tokens[valueIdx].number}
+	unitIdx := valueIdx + 1
+	if unitIdx+1 < len(tokens) && tokens[unitIdx].plusMinus && tokens[unitIdx+1].number != "" {
+		problem.Uncertainty = tokens[unitIdx+1].number
+		unitIdx += 2
+	}
+	if unitIdx >= len(tokens) || tokens[unitIdx].unit == "" {
+		return WordProblem{}, fmt.Errorf("no unit found after %s in '%s'", problem.Input, text)
+	}
+	problem.FromUnit = tokens[unitIdx].unit
+
+	for idx, token := range tokens {
+		if token.unit != "" && idx != unitIdx {
+			problem.ToUnit = token.unit
+			return problem, nil
+		}
+	}
+	return WordProblem{}, fmt.Errorf("no unit to convert to found in '%s'", text)
+}
+
+// Answer converts the word problem with conv and rounds the result.
+func (p WordProblem) Answer(conv Converter, rounding Rounding) (Quantity, error) {
+	q, err := p.ToQuestion(conv, rounding)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{*q.CorrectAnswer, q.TargetUoM}, nil
+}
+
+// ToQuestion builds the word problem as a worksheet question with its answer
+// key rounded, failing when the question cannot be answered.
+func (p WordProblem) ToQuestion(conv Converter, rounding Rounding) (Question, error) {
+	q, err := buildQuestion([]string{p.inputCell(), p.FromUnit, p.ToUnit}, positionalLayout(), conv, rounding.Round)
+	if err != nil {
+		return Question{}, err
+	}
+	if q.CorrectAnswer == nil {
+		return Question{}, errors.New(q.Problem)
+	}
+	return q, nil
+}
+
+// inputCell writes the value and any uncertainty as an Input cell.
+func (p WordProblem) inputCell() string {
+	if p.Uncertainty == "" {
+		return p.Input
+	}
+	return p.Input + " ± " + p.Uncertainty
+}
+
+// expandWordProblem fills in the Input, From Unit and To Unit of a row from
+// its sentence: the only cell of a row without a header, or the Text column.
+// Cells the row gives take precedence over the sentence.
+func expandWordProblem(data []string, layout columnLayout) ([]string, columnLayout, error) {
+	text := layout.value(data, TextColumn)
+	if layout.positional && len(data) == 1 {
+		text = strings.TrimSpace(data[0])
+	}
+	if text == "" {
+		return data, layout, nil
+	}
+	problem, err := ParseWordProblem(text)
+	if err != nil {
+		return nil, columnLayout{}, &ColumnError{TextColumn, err}
+	}
+	if layout.positional {
+		return []string{problem.inputCell(), problem.FromUnit, problem.ToUnit}, layout, nil
+	}
+
+	row := slices.Clone(data)
+	indexes := maps.Clone(layout.indexes)
+	parsed := map[string]string{InputColumn: problem.inputCell(), FromUnitColumn: problem.FromUnit, ToUnitColumn: problem.ToUnit}
+	for _, column := range requiredColumns {
+		if layout.value(data, column) != "" {
+			continue
+		}
+		if idx, ok := indexes[column]; ok && idx < len(row) {
+			row[idx] = parsed[column]
+			continue
+		}
+		indexes[column] = len(row)
+		row = append(row, parsed[column])
+	}
+	return row, columnLayout{indexes, false}, nil
+}
+
+// tokenizeWords splits a sentence into numbers, units, "per" and other words,
+// matching the longest unit phrase first.
+func tokenizeWords(text string) []wordToken {
+	text = strings.ToLower(text)
+	text = strings.NewReplacer("+/-", " ± ", "+-", " ± ", "±", " ± ").Replace(text)
+	text = strings.NewReplacer("º", "°", "° ", "°", "/", " per ").Replace(text)
+
+	words := make([]string, 0)
+	for _, word := range strings.Fields(text) {
+		if number := leadingNumber.FindString(word); number != "" && number != word {
+			words = append(words, number)
+			word = word[len(number):]
+		}
+		word = strings.Trim(word, `?!,;:"'()`)
+		if !leadingNumber.MatchString(word) {
+			word = strings.TrimRight(word, ".")
+		}
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+
+	tokens := make([]wordToken, 0, len(words))
+	for idx := 0; idx < len(words); idx++ {
+		if number := leadingNumber.FindString(words[idx]); number != "" && number == words[idx] {
+			tokens = append(tokens, wordToken{number: number})
+			continue
+		}
+		if words[idx] == "per" {
+			tokens = append(tokens, wordToken{per: true})
+			continue
+		}
+		if words[idx] == "±" {
+			tokens = append(tokens, wordToken{plusMinus: true})
+			continue
+		}
+
+		token := wordToken{}
+		for length := min(longestUnitPhrase, len(words)-idx); length > 0; length-- {
+			if unit, ok := unitPhrases[strings.Join(words[idx:idx+length], " ")]; ok {
+				token.unit = unit
+				idx += length - 1
+				break
+			}
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// composeRates joins a volume, "per" and a time into a flow rate unit.
+func composeRates(tokens []wordToken) []wordToken {
+	composed := make([]wordToken, 0, len(tokens))
+	for idx := 0; idx < len(tokens); idx++ {
+		if idx+2 < len(tokens) && tokens[idx+1].per {
+			volumeDim, _, _ := lookupUnit(tokens[idx].unit)
+			timeDim, _, _ := lookupUnit(tokens[idx+2].unit)
+			if volumeDim == "volume" && timeDim == "time" {
+				composed = append(composed, wordToken{unit: tokens[idx].unit + " per " + strings.TrimSuffix(tokens[idx+2].unit, "s")})
+				idx += 2
+				continue
+			}
+		}
+		composed = append(composed, tokens[idx])
+	}
+	return composed
+}
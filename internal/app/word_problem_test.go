@@ -0,0 +1,89 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWordProblem(t *testing.T) {
+	for _, tc := range []struct {
+		text     string
+		expected WordProblem
+	}{
+		{"Convert 100°F to Rankine", WordProblem{"100", "fahrenheit", "rankine", ""}},
+		{"How many cups are in 3 liters?", WordProblem{"3", "liters", "cups", ""}},
+		{"What is -40 degrees Celsius in Fahrenheit?", WordProblem{"-40", "celsius", "fahrenheit", ""}},
+		{"Express 2.50 gal in quarts.", WordProblem{"2.50", "gallons", "quarts", ""}},
+		{"convert 1 cubic foot into litres", WordProblem{"1", "cubic feet", "liters", ""}},
+		{"How many tsp is 1 tbsp?", WordProblem{"1", "tablespoons", "teaspoons", ""}},
+		{"Convert 250mL to fl oz", WordProblem{"250", "milliliters", "fluid ounces", ""}},
+		{"12 L/min to gallons per hour", WordProblem{"12", "liters per minute", "gallons per hour", ""}},
+		{"How many inches... er, cubic inches are in 1 pint?", WordProblem{"1", "pints", "cubic inches", ""}},
+		{"Convert 20 degrees Réaumur to kelvin", WordProblem{"20", "reaumur", "kelvin", ""}},
+		{"Convert 100 ± 0.5 °C to Fahrenheit", WordProblem{"100", "celsius", "fahrenheit", "0.5"}},
+		{"How many cups are in 2.00+/-0.05L?", WordProblem{"2.00", "liters", "cups", "0.05"}},
+	} {
+		problem, err := ParseWordProblem(tc.text)
+		assert.NoError(t, err, tc.text)
+		assert.Equal(t, tc.expected, problem, tc.text)
+	}
+
+	_, err := ParseWordProblem("Convert Fahrenheit to Celsius")
+	assert.EqualError(t, err, "no value found in 'Convert Fahrenheit to Celsius'")
+	_, err = ParseWordProblem("Convert 100 to Celsius")
+	assert.EqualError(t, err, "no unit found after 100 in 'Convert 100 to Celsius'")
+	_, err = ParseWordProblem("What is 3 liters?")
+	assert.EqualError(t, err, "no unit to convert to found in 'What is 3 liters?'")
+}
+
+func TestWordProblemAnswer(t *testing.T) {
+	answer, err := WordProblem{"3", "liters", "cups", ""}.Answer(TableConverter{}, DefaultRounding)
+	assert.NoError(t, err)
+	assert.Equal(t, Quantity{12.7, "cups"}, answer)
+
+	q, err := WordProblem{"100", "celsius", "fahrenheit", "0.5"}.ToQuestion(TableConverter{}, DefaultRounding)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"100 ± 0.5", "celsius", "fahrenheit", "212 ± 0.9"}, q.ToGrid())
+
+	_, err = WordProblem{"-500", "kelvin", "celsius", ""}.Answer(TableConverter{}, DefaultRounding)
+	assert.EqualError(t, err, "-500 kelvin is colder than absolute zero (0 kelvin)")
+	_, err = WordProblem{"3", "liters", "hours", ""}.Answer(TableConverter{}, DefaultRounding)
+	assert.EqualError(t, err, "invalid conversion: from liters, to hours")
+}
+
+func TestWordProblemWorksheet(t *testing.T) {
+	ws, err := NewWorksheet([][]string{
+		{"Convert 100°F to Rankine"},
+		{"2", "cups", "tablespoons"},
+		{"How many cups are in 3 liters?"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, ws.Questions, 3)
+	assert.Equal(t, "fahrenheit", ws.Questions[0].InputUoM)
+	assert.Equal(t, "rankine", ws.Questions[0].TargetUoM)
+	assert.Equal(t, 559.7, *ws.Questions[0].CorrectAnswer)
+	assert.Equal(t, 12.7, *ws.Questions[2].CorrectAnswer)
+
+	// cells the row gives override the sentence
+	ws, err = NewWorksheet([][]string{
+		{"ID", "Text", "To Unit", "Points"},
+		{"q1", "How many cups are in 3 liters?", "", "2"},
+		{"q2", "How many cups are in 3 liters?", "pints", ""},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "cups", ws.Questions[0].TargetUoM)
+	assert.Equal(t, 2., ws.Questions[0].Points)
+	assert.Equal(t, "pints", ws.Questions[1].TargetUoM)
+	assert.Equal(t, 6.3, *ws.Questions[1].CorrectAnswer)
+
+	_, err = NewWorksheet([][]string{{"Convert 100 to Celsius"}})
+	assert.EqualError(t, err, "no unit found after 100 in 'Convert 100 to Celsius'")
+
+	diagnostics := LintWorksheet([][]string{
+		{"Word Problem", "Points"},
+		{"Convert 100°F to Rankine", "1"},
+		{"Convert some water to ice", "1"},
+	})
+	assert.Equal(t, []Diagnostic{{3, 1, SeverityError, "no value found in 'Convert some water to ice'"}}, diagnostics)
+}
@@ -8,62 +8,263 @@ import (
 
 type Worksheet struct {
 	Questions []Question
+
+	UnknownColumns []string // header columns that were ignored
+
+	Groups []Group // in order of appearance
+
+	DefaultTolerance Tolerance // used by questions without their own tolerance
+
+	Metadata Metadata
 }
 
 type Question struct {
-	Input     float64
-	InputUoM  string
-	TargetUoM string
+	ID          string // optional, matches responses by ID instead of position
+	Type        QuestionType
+	Choices     []string
+	Input       float64
+	InputText   string   // input as written, for significant figures
+	Uncertainty *float64 // measurement uncertainty of the input, nil when not given
+	InputUoM    string
+	TargetUoM   string
+
+	CorrectAnswer     *float64   // nil for an invalid question
+	ExpectedAnswer    *float64   // teacher's answer, overriding the computed one when given
+	ComputedAnswer    *float64   // answer computed by the conversion engine
+	AnswerUncertainty *float64   // uncertainty propagated to the answer, nil when there is none
+	Tolerance         *Tolerance // nil to use the worksheet default
+	Points            float64
+	Step              int // 1-based step of a chained question, 0 otherwise
+	Group             string
+	Credit            Credit
+
+	AllowImpossible bool   // skips domain validation, for intentionally tricky questions
+	Problem         string // why the answer cannot be computed, "" when it can
 
-	CorrectAnswer *float64 // nil for an invalid question
+	exact         float64 // unrounded answer the tolerance is measured against
+	computedExact float64
+	groupBest     int // best count given for the question's group, 0 when not given
 }
 
 const QuestionLength = 3
 
+const DefaultPoints = 1.
+
 func NewWorksheet(data [][]string) (Worksheet, error) {
-	ws := Worksheet{}
+	return WorksheetParser(TableConverter{})(data)
+}
+
+// WorksheetParser returns a parse func that computes the answer key with conv.
+func WorksheetParser(conv Converter) func([][]string) (Worksheet, error) {
+	return RoundedWorksheetParser(conv, nil)
+}
+
+// RoundedWorksheetParser is WorksheetParser with a rounding that overrides
+// the worksheet's own, unless it is nil.
+func RoundedWorksheetParser(conv Converter, rounding *Rounding) func([][]string) (Worksheet, error) {
+	return func(data [][]string) (Worksheet, error) {
+		metadata, data, err := parseMetadata(data)
+		if err != nil {
+			return Worksheet{}, err
+		}
+		if rounding != nil {
+			metadata.Rounding = rounding
+		}
 
-	for _, row := range data {
-		question, err := buildQuestion(row)
+		layout, rows, unknown, err := detectLayout(data)
 		if err != nil {
 			return Worksheet{}, err
 		}
 
-		ws.Questions = append(ws.Questions, question)
-	}
+		ws := Worksheet{UnknownColumns: unknown, Metadata: metadata}
+		if metadata.Tolerance != nil {
+			ws.DefaultTolerance = *metadata.Tolerance
+		}
 
-	return ws, nil
+		ids := make(map[string]struct{})
+		for _, row := range rows {
+			questions, err := buildQuestions(row, layout, conv, ws.round)
+			if err != nil {
+				return Worksheet{}, err
+			}
+			ws.Groups, err = addGroup(ws.Groups, questions[0].Group, questions[0].groupBest)
+			if err != nil {
+				return Worksheet{}, err
+			}
+			for _, question := range questions {
+				if question.ID != "" {
+					if _, ok := ids[strings.ToLower(question.ID)]; ok {
+						return Worksheet{}, fmt.Errorf("duplicate question ID '%s'", question.ID)
+					}
+					ids[strings.ToLower(question.ID)] = struct{}{}
+				}
+
+				ws.Questions = append(ws.Questions, question)
+			}
+		}
+
+		return ws, nil
+	}
 }
 
-func buildQuestion(data []string) (Question, error) {
-	q := Question{}
+func buildQuestion(data []string, layout columnLayout, conv Converter, round func(float64) float64) (Question, error) {
+	q := Question{Points: DefaultPoints}
 
-	if len(data) != QuestionLength {
+	if layout.positional && len(data) != QuestionLength {
 		return Question{},
 			fmt.Errorf("invalid question provided: %s", strings.Join(data, ","))
 	}
 
-	input, err := strconv.ParseFloat(data[0], 64)
+	questionType, err := parseQuestionType(layout.value(data, TypeColumn))
 	if err != nil {
-		return Question{},
-			fmt.Errorf("invalid input number(s) given: %s", strings.Join(data, ","))
+		return Question{}, &ColumnError{TypeColumn,
+			fmt.Errorf("%v in question: %s", err, strings.Join(data, ","))}
+	}
+	q.Type = questionType
+	q.ID = layout.value(data, IDColumn)
+	q.Group = layout.value(data, GroupColumn)
+
+	credit, err := parseCredit(layout.value(data, CreditColumn))
+	if err != nil {
+		return Question{}, &ColumnError{CreditColumn,
+			fmt.Errorf("%v in question: %s", err, strings.Join(data, ","))}
+	}
+	q.Credit = credit
+
+	q.groupBest, err = parseBest(layout.value(data, BestColumn))
+	if err != nil {
+		return Question{}, &ColumnError{BestColumn,
+			fmt.Errorf("%v in question: %s", err, strings.Join(data, ","))}
+	}
+	q.Choices = parseChoices(layout.value(data, ChoicesColumn))
+
+	inputStr, uncertainty, err := splitUncertainty(layout.value(data, InputColumn))
+	if err != nil {
+		return Question{}, &ColumnError{InputColumn,
+			fmt.Errorf("%v in question: %s", err, strings.Join(data, ","))}
+	}
+	input, err := strconv.ParseFloat(inputStr, 64)
+	if err != nil {
+		return Question{}, &ColumnError{InputColumn,
+			fmt.Errorf("invalid input number(s) given: %s", strings.Join(data, ","))}
 	}
 	q.Input = input
+	q.InputText = inputStr
+	q.Uncertainty = uncertainty
 
-	inputUom := data[1]
-	q.InputUoM = strings.TrimSpace(strings.ToLower(inputUom))
+	inputUom := layout.value(data, FromUnitColumn)
+	q.InputUoM = strings.ToLower(inputUom)
 
-	targetUoM := data[2]
-	q.TargetUoM = strings.TrimSpace(strings.ToLower(targetUoM))
+	targetUoM := layout.value(data, ToUnitColumn)
+	q.TargetUoM = strings.ToLower(targetUoM)
 
-	answer, err := ConvertUnits(q.InputUoM, q.TargetUoM, q.Input)
-	if err == nil {
-		q.CorrectAnswer = &answer
+	if toleranceStr := layout.value(data, ToleranceColumn); toleranceStr != "" {
+		tolerance, err := ParseTolerance(toleranceStr)
+		if err != nil {
+			return Question{}, &ColumnError{ToleranceColumn,
+				fmt.Errorf("%v in question: %s", err, strings.Join(data, ","))}
+		}
+		q.Tolerance = &tolerance
+	}
+
+	if pointsStr := layout.value(data, PointsColumn); pointsStr != "" {
+		points, err := strconv.ParseFloat(pointsStr, 64)
+		if err != nil || points < 0 {
+			return Question{}, &ColumnError{PointsColumn,
+				fmt.Errorf("invalid points '%s' in question: %s", pointsStr, strings.Join(data, ","))}
+		}
+		q.Points = points
+	}
+
+	if allowStr := layout.value(data, AllowImpossibleColumn); allowStr != "" {
+		allow, err := parseSwitch(allowStr)
+		if err != nil {
+			return Question{}, &ColumnError{AllowImpossibleColumn,
+				fmt.Errorf("invalid allow impossible '%s' in question: %s", allowStr, strings.Join(data, ","))}
+		}
+		q.AllowImpossible = allow
+	}
+
+	q.setKey(conv, round)
+
+	if expectedStr := layout.value(data, ExpectedAnswerColumn); expectedStr != "" {
+		expected, err := strconv.ParseFloat(expectedStr, 64)
+		if err != nil {
+			return Question{}, &ColumnError{ExpectedAnswerColumn,
+				fmt.Errorf("invalid expected answer '%s' in question: %s", expectedStr, strings.Join(data, ","))}
+		}
+		q.ExpectedAnswer = &expected
+		// the teacher's answer does not make a question that cannot be
+		// computed, such as one with unknown units, valid
+		if q.ComputedAnswer != nil {
+			// rounded like responses, so one published more precisely still matches
+			key := expected
+			if !q.Type.isChoice() {
+				key = round(expected)
+			}
+			q.CorrectAnswer, q.exact = &key, expected
+		}
 	}
 
 	return q, nil
 }
 
+// setKey computes the question's answer key, recording the problem when it
+// cannot be computed.
+func (q *Question) setKey(conv Converter, round func(float64) float64) {
+	var err error
+	q.ComputedAnswer, q.computedExact, err = computeKey(*q, conv, round)
+	q.CorrectAnswer, q.exact = q.ComputedAnswer, q.computedExact
+	q.AnswerUncertainty = nil
+	q.Problem = ""
+	if err != nil {
+		q.Problem = err.Error()
+		return
+	}
+	q.AnswerUncertainty = propagateUncertainty(*q, conv, round)
+}
+
+// computeKey answers the question with conv, returning the rounded key and the
+// exact answer. The key is nil when the question cannot be answered or asks
+// about a quantity that cannot exist.
+func computeKey(q Question, conv Converter, round func(float64) float64) (*float64, float64, error) {
+	if err := q.domainError(); err != nil {
+		return nil, 0, err
+	}
+	exact, err := answerQuestion(q, conv, round)
+	if err != nil {
+		return nil, 0, err
+	}
+	answer := exact
+	if !q.Type.isChoice() {
+		answer = round(exact)
+	}
+	return &answer, exact, nil
+}
+
+// round rounds a value like the worksheet's answer key.
+func (ws Worksheet) round(value float64) float64 {
+	return ws.rounding().Round(value)
+}
+
+// rounding is the worksheet's rounding, DefaultRounding unless it sets one.
+func (ws Worksheet) rounding() Rounding {
+	if ws.Metadata.Rounding != nil {
+		return *ws.Metadata.Rounding
+	}
+	return DefaultRounding
+}
+
+// HasQuestionIDs reports whether responses are matched to questions by ID.
+func (ws Worksheet) HasQuestionIDs() bool {
+	for _, q := range ws.Questions {
+		if q.ID != "" {
+			return true
+		}
+	}
+	return false
+}
+
 func (ws Worksheet) Key() []*float64 {
 	key := make([]*float64, 0, len(ws.Questions))
 	for _, q := range ws.Questions {
@@ -76,6 +277,70 @@ func (q *Question) ToGrid() []string {
 	correctStr := ""
 	if q.CorrectAnswer != nil {
 		correctStr = strconv.FormatFloat(*q.CorrectAnswer, 'f', -1, 64)
+		if q.Type.isChoice() {
+			correctStr += " (" + q.choiceLabel(int(*q.CorrectAnswer)) + ")"
+		}
+		if q.AnswerUncertainty != nil {
+			correctStr += " " + formatUncertainty(*q.AnswerUncertainty)
+		}
+	}
+	inputStr := strconv.FormatFloat(q.Input, 'f', -1, 64)
+	if q.Uncertainty != nil {
+		inputStr += " " + formatUncertainty(*q.Uncertainty)
+	}
+	return []string{inputStr, q.InputUoM, q.TargetUoM, correctStr}
+}
+
+// Accepts reports whether response answers the question within its tolerance,
+// falling back to the worksheet default, or within its propagated
+// uncertainty when the worksheet accepts uncertainty.
+func (ws Worksheet) Accepts(questionIdx int, response float64) bool {
+	q := ws.Questions[questionIdx]
+	if q.CorrectAnswer == nil {
+		return false
+	}
+	if q.Type.isChoice() {
+		return response == *q.CorrectAnswer
+	}
+	if ws.Metadata.AcceptUncertainty && q.withinUncertainty(response) {
+		return true
+	}
+	return ws.withinTolerance(q, *q.CorrectAnswer, q.exact, response)
+}
+
+func (ws Worksheet) withinTolerance(q Question, key, exact, response float64) bool {
+	tolerance := ws.DefaultTolerance
+	if q.Tolerance != nil {
+		tolerance = *q.Tolerance
+	}
+	return tolerance.Accepts(key, exact, response, ws.rounding())
+}
+
+// Discrepancies lists the questions whose teacher-supplied answer disagrees
+// with the computed answer beyond the question's tolerance, or is changed by
+// the worksheet's rounding.
+func (ws Worksheet) Discrepancies() []Disagreement {
+	discrepancies := make([]Disagreement, 0)
+	for idx, q := range ws.Questions {
+		if q.ExpectedAnswer == nil {
+			continue
+		}
+		agrees := q.ComputedAnswer != nil
+		if agrees && q.Type.isChoice() {
+			agrees = *q.ExpectedAnswer == *q.ComputedAnswer
+		} else if agrees {
+			// an expected answer the worksheet rounding changes is graded as another answer
+			agrees = *q.CorrectAnswer == *q.ExpectedAnswer &&
+				ws.withinTolerance(q, *q.ComputedAnswer, q.computedExact, *q.ExpectedAnswer)
+		}
+		if !agrees {
+			discrepancies = append(discrepancies, Disagreement{
+				QuestionIdx: idx,
+				Question:    q,
+				Primary:     q.ExpectedAnswer,
+				Secondary:   q.ComputedAnswer,
+			})
+		}
 	}
-	return []string{strconv.FormatFloat(q.Input, 'f', -1, 64), q.InputUoM, q.TargetUoM, correctStr}
+	return discrepancies
 }
@@ -31,6 +31,142 @@ func TestNewWorksheet(t *testing.T) {
 	assert.Equal(t, ws, Worksheet{})
 }
 
+func TestNewWorksheetWithHeader(t *testing.T) {
+	testData := [][]string{
+		{"To Unit", "Notes", "Input", "From Unit"},
+		{"cups", "easy one", "1.10", "liters"},
+		{"Kelvin", "", "100", "Celsius"},
+	}
+
+	ws, err := NewWorksheet(testData)
+	assert.NoError(t, err)
+	assert.Len(t, ws.Questions, 2)
+	assert.Equal(t, []string{"Notes"}, ws.UnknownColumns)
+	assert.Equal(t, 1.1, ws.Questions[0].Input)
+	assert.Equal(t, "liters", ws.Questions[0].InputUoM)
+	assert.Equal(t, "cups", ws.Questions[0].TargetUoM)
+	assert.Equal(t, 373.2, *ws.Questions[1].CorrectAnswer)
+
+	// short rows are allowed once columns are named
+	testData = [][]string{
+		{"input", "from", "to", "comment"},
+		{"100", "gallons", "liters"},
+	}
+	ws, err = NewWorksheet(testData)
+	assert.NoError(t, err)
+	assert.Len(t, ws.Questions, 1)
+
+	testData = [][]string{
+		{"Input", "From Unit"},
+		{"100", "gallons"},
+	}
+	_, err = NewWorksheet(testData)
+	assert.Error(t, err)
+
+	testData = [][]string{
+		{"Input", "From Unit", "To Unit", "Input"},
+	}
+	_, err = NewWorksheet(testData)
+	assert.Error(t, err)
+
+	// a row is only a header when it names the required columns or Text
+	assert.True(t, isHeaderRow([]string{"value", "from", "to"}))
+	assert.True(t, isHeaderRow([]string{"ID", "Word Problem"}))
+	assert.False(t, isHeaderRow([]string{"Question", "Points"}))
+	assert.False(t, isHeaderRow([]string{"100", "liters", "to"}))
+}
+
+func TestWorksheetTolerance(t *testing.T) {
+	testData := [][]string{
+		{"Input", "From Unit", "To Unit", "Tolerance"},
+		{"100", "liters", "gallons", "0.1"},
+		{"100", "liters", "gallons", "1%"},
+		{"100", "liters", "gallons", ""},
+	}
+
+	ws, err := NewWorksheet(testData)
+	assert.NoError(t, err)
+	assert.Equal(t, Tolerance{Absolute, 0.1}, *ws.Questions[0].Tolerance)
+	assert.Equal(t, Tolerance{Percent, 1}, *ws.Questions[1].Tolerance)
+	assert.Nil(t, ws.Questions[2].Tolerance)
+
+	assert.True(t, ws.Accepts(0, 26.34))
+	assert.False(t, ws.Accepts(0, 26.2))
+	assert.True(t, ws.Accepts(1, 26.2))
+	assert.False(t, ws.Accepts(2, 26.34))
+
+	ws.DefaultTolerance = Tolerance{Absolute, 0.5}
+	assert.True(t, ws.Accepts(2, 26.0))
+
+	testData[1][3] = "a lot"
+	_, err = NewWorksheet(testData)
+	assert.Error(t, err)
+}
+
+func TestWorksheetPoints(t *testing.T) {
+	testData := [][]string{
+		{"Input", "From Unit", "To Unit", "Weight"},
+		{"100", "liters", "gallons", "2.5"},
+		{"100", "liters", "gallons", ""},
+	}
+
+	ws, err := NewWorksheet(testData)
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, ws.Questions[0].Points)
+	assert.Equal(t, DefaultPoints, ws.Questions[1].Points)
+
+	testData[1][3] = "-1"
+	_, err = NewWorksheet(testData)
+	assert.Error(t, err)
+}
+
+func TestExpectedAnswer(t *testing.T) {
+	ws, err := NewWorksheet([][]string{
+		{"Input", "From Unit", "To Unit", "Expected Answer", "Tolerance"},
+		{"100", "liters", "gallons", "26.42", ""},
+		{"100", "liters", "gallons", "26.5", ""},
+		{"100", "liters", "gallons", "26.5", "0.1"},
+		{"100", "liters", "gallons", "", ""},
+		{"100", "not a unit", "gallons", "3", ""},
+	})
+	assert.NoError(t, err)
+
+	// an answer published more precisely than the worksheet rounding
+	assert.Equal(t, 26.4, *ws.Questions[0].CorrectAnswer)
+	assert.Equal(t, 26.42, *ws.Questions[0].ExpectedAnswer)
+	assert.True(t, ws.Accepts(0, 26.42))
+	assert.True(t, ws.Accepts(0, 26.4))
+	assert.False(t, ws.Accepts(0, 26.3))
+	assert.Nil(t, ws.Questions[3].ExpectedAnswer)
+	assert.Equal(t, 26.4, *ws.Questions[3].CorrectAnswer)
+	assert.Nil(t, ws.Questions[4].CorrectAnswer)
+	assert.Equal(t, 3., *ws.Questions[4].ExpectedAnswer)
+
+	// students are graded against the teacher's answer
+	assert.True(t, ws.Accepts(1, 26.5))
+	assert.False(t, ws.Accepts(1, 26.4))
+
+	discrepancies := ws.Discrepancies()
+	assert.Len(t, discrepancies, 3)
+	assert.Equal(t, 0, discrepancies[0].QuestionIdx)
+	assert.Equal(t, 1, discrepancies[1].QuestionIdx)
+	assert.Equal(t, 4, discrepancies[2].QuestionIdx)
+	assert.Nil(t, discrepancies[2].Secondary)
+
+	assert.Equal(t, [][]string{
+		{"Question", "Input", "From Unit", "To Unit", "Expected Answer", "Computed Answer"},
+		{"1", "100", "liters", "gallons", "26.42", "26.4"},
+		{"2", "100", "liters", "gallons", "26.5", "26.4"},
+		{"5", "100", "not a unit", "gallons", "3", "invalid"},
+	}, DisagreementsToGrid(discrepancies, "Expected Answer", "Computed Answer"))
+
+	_, err = NewWorksheet([][]string{
+		{"Input", "From Unit", "To Unit", "Expected"},
+		{"100", "liters", "gallons", "about 26"},
+	})
+	assert.Error(t, err)
+}
+
 func TestKey(t *testing.T) {
 	testData := [][]string{
 		{"1.10", "liters", "cups"},
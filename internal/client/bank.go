@@ -0,0 +1,115 @@
+package client
+
+import (
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/dougdoenges/flexion-coding-challenge/internal/app"
+	"github.com/dougdoenges/flexion-coding-challenge/internal/parser/file"
+)
+
+func runBank(args []string) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "add":
+			runBankAdd(args[1:])
+			return
+		case "assemble":
+			runBankAssemble(args[1:])
+			return
+		}
+	}
+	log.Fatal("bank command must be 'add' or 'assemble'")
+}
+
+func runBankAdd(args []string) {
+	flags := flag.NewFlagSet("bank add", flag.ExitOnError)
+	bankFile := flags.String("bank", "", "Give file path for the question bank, created if missing (required)")
+	worksheetFile := flags.String("worksheet", "", "Give file path for a worksheet whose questions are added (required)")
+	difficultyStr := flags.String("difficulty", string(app.DefaultDifficulty), "Give difficulty of questions without their own: easy, medium or hard")
+	topic := flags.String("topic", "", "Give topic of questions without their own")
+	flags.Parse(args)
+
+	if *bankFile == "" {
+		log.Fatal("bank file path is required")
+	}
+	if *worksheetFile == "" {
+		log.Fatal("worksheet file path is required")
+	}
+	difficulty, err := app.ParseDifficulty(*difficultyStr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	bank := readBank(*bankFile)
+	before := len(bank.Entries)
+
+	if err := bank.Add(readRows(*worksheetFile), difficulty, *topic); err != nil {
+		log.Fatal(err)
+	}
+
+	writeGrid(*bankFile, bank.ToGrid())
+	log.Printf("Success! %d question(s) added to the bank, which now holds %d: %s",
+		len(bank.Entries)-before, len(bank.Entries), *bankFile)
+}
+
+func runBankAssemble(args []string) {
+	flags := flag.NewFlagSet("bank assemble", flag.ExitOnError)
+	bankFile := flags.String("bank", "", "Give file path for the question bank (required)")
+	outputLocation := flags.String("output", "", "Give file path and name for the assembled worksheet (required)")
+	dimensions := flags.String("dimensions", "", "Give comma separated dimensions to include (default all)")
+	difficultyStr := flags.String("difficulty", "", "Give difficulty of the questions: easy, medium or hard (default all)")
+	topics := flags.String("topics", "", "Give comma separated topics to include (default all)")
+	count := flags.Int("count", 10, "Give number of questions")
+	seed := flags.Int64("seed", time.Now().UnixNano(), "Give random seed to reproduce a worksheet")
+	flags.Parse(args)
+
+	if *bankFile == "" {
+		log.Fatal("bank file path is required")
+	}
+	if *outputLocation == "" {
+		log.Fatal("output file is required")
+	}
+
+	opts := app.AssembleOptions{
+		Dimensions: splitList(*dimensions),
+		Topics:     splitList(*topics),
+		Count:      *count,
+		Seed:       *seed,
+	}
+	if *difficultyStr != "" {
+		difficulty, err := app.ParseDifficulty(*difficultyStr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts.Difficulty = difficulty
+	}
+
+	if _, err := os.Stat(*bankFile); err != nil {
+		log.Fatal(err)
+	}
+	rows, err := readBank(*bankFile).Assemble(opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	writeGrid(*outputLocation, rows)
+	log.Printf("Success! Worksheet assembled with seed %d can be found here: %s", opts.Seed, *outputLocation)
+}
+
+// readBank reads the question bank, or returns an empty bank when the file does not exist yet.
+func readBank(path string) app.Bank {
+	bankReader, err := file.NewReader[app.Bank](path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return app.Bank{}
+	}
+	bank, err := bankReader.Read(app.NewBank)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return bank
+}
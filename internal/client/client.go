@@ -3,56 +3,272 @@ package client
 import (
 	"flag"
 	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/dougdoenges/flexion-coding-challenge/internal/app"
 	"github.com/dougdoenges/flexion-coding-challenge/internal/parser/file"
 )
 
 func Run() {
-	worksheetFile := flag.String("worksheet", "", "Give file path for worksheet (required)")
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "generate":
+			runGenerate(os.Args[2:])
+			return
+		case "validate":
+			runValidate(os.Args[2:])
+			return
+		case "bank":
+			runBank(os.Args[2:])
+			return
+		case "query":
+			runQuery(os.Args[2:])
+			return
+		}
+	}
+	runGrade()
+}
+
+func runGrade() {
+	worksheetFile := flag.String("worksheet", "", "Give file path for worksheet (required unless grading variants)")
 	responsesFile := flag.String("responses", "", "Give file path for student responses to grade (required)")
-	outputLocation := flag.String("output", "", "Give file path and name for output (required)")
+	outputLocation := flag.String("output", "", "Give file path and name for output (required unless writing back to the workbook)")
+	workbookFile := flag.String("workbook", "", "Give file path for one XLSX workbook holding both the worksheet and the responses")
+	worksheetSheet := flag.String("worksheet-sheet", "Worksheet", "Give name of the workbook sheet holding the worksheet")
+	responsesSheet := flag.String("responses-sheet", "Responses", "Give name of the workbook sheet holding the responses")
+	writeBack := flag.Bool("write-back", false, "Write the graded results to the workbook as a new sheet")
+	resultsSheet := flag.String("results-sheet", "Results", "Give name of the workbook sheet the results are written to")
+	converterName := flag.String("converter", app.DefaultConverterName, "Give conversion backend used to compute the answer key")
+	roundingStr := flag.String("rounding", "", "Give rounding of the answer key and responses, e.g. 2dp, 3sf, auto or half-even 2dp; overrides the worksheet's")
+	sigFigs := flag.Bool("sig-figs", false, "Grade the significant figures of responses as well as their values")
+	acceptUncertainty := flag.Bool("accept-uncertainty", false, "Accept responses within the uncertainty of inputs such as 100 ± 0.5, propagated to the answer")
+	toleranceStr := flag.String("tolerance", "", "Give default grading tolerance: absolute (0.5), percent (2%) or decimal places (2dp)")
+	crossCheckName := flag.String("cross-check", "", "Give a second conversion backend to compare the answer key against")
+	discrepancyReport := flag.String("discrepancies", "", "Give file path for a report of expected answers that disagree with the computed answers")
+	variants := flag.Bool("variants", false, "Grade each student against their own generated worksheet variant, using the generate flags")
+	generatorSource := registerGeneratorFlags(flag.CommandLine, 0)
 	flag.Parse()
 
-	if *worksheetFile == "" {
+	if *variants && (*worksheetFile != "" || *workbookFile != "") {
+		log.Fatal("variants are graded against generated worksheets, not a worksheet or workbook file")
+	}
+
+	// sheets are only selected by name within a workbook
+	sheetOf := func(string) string { return "" }
+	if *workbookFile != "" {
+		if *worksheetFile != "" || *responsesFile != "" {
+			log.Fatal("worksheet and response file paths cannot be given with a workbook")
+		}
+		if file.FileType(strings.ToLower(filepath.Ext(*workbookFile))) != file.EXCEL {
+			log.Fatal("workbook must be an XLSX file")
+		}
+		// writing the results would replace a sheet they are graded from
+		for _, sheet := range []string{*worksheetSheet, *responsesSheet, metadataSheet} {
+			if *writeBack && strings.EqualFold(*resultsSheet, sheet) {
+				log.Fatalf("results sheet must not be the %s sheet", sheet)
+			}
+		}
+		*worksheetFile, *responsesFile = *workbookFile, *workbookFile
+		sheetOf = func(name string) string { return name }
+	} else if *writeBack {
+		log.Fatal("workbook file path is required to write back results")
+	}
+
+	if *worksheetFile == "" && !*variants {
 		log.Fatal("worksheet file path is required")
 	}
 	if *responsesFile == "" {
 		log.Fatal("response file path is required")
 	}
-	if *outputLocation == "" {
+	if *outputLocation == "" && !*writeBack {
 		log.Fatal("output file is required")
 	}
+	if *variants && !isFlagSet("seed") {
+		log.Fatal("seed used to generate the variants is required")
+	}
 
-	worksheetReader, err := file.NewReader[app.Worksheet](*worksheetFile)
+	defaultTolerance, err := app.ParseTolerance(*toleranceStr)
 	if err != nil {
 		log.Fatal(err)
 	}
-	worksheet, err := worksheetReader.Read(app.NewWorksheet)
+
+	var rounding *app.Rounding
+	if *roundingStr != "" {
+		r, err := app.ParseRounding(*roundingStr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rounding = &r
+	}
+
+	converter, err := app.GetConverter(*converterName)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	var crossCheck app.Converter
+	if *crossCheckName != "" {
+		crossCheck, err = app.GetConverter(*crossCheckName)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	// prepare parses a worksheet and applies the run's grading options
+	prepare := func(data [][]string) (app.Worksheet, error) {
+		worksheet, err := app.RoundedWorksheetParser(converter, rounding)(data)
+		if err != nil {
+			return app.Worksheet{}, err
+		}
+		if *toleranceStr != "" {
+			worksheet.DefaultTolerance = defaultTolerance
+		}
+		if *sigFigs {
+			worksheet.Metadata.SignificantFigures = true
+		}
+		if *acceptUncertainty {
+			worksheet.Metadata.AcceptUncertainty = true
+		}
+		for idx, q := range worksheet.Questions {
+			if q.CorrectAnswer == nil && q.Problem != "" {
+				log.Printf("Question %d is invalid: %s", idx+1, q.Problem)
+			}
+		}
+		if len(worksheet.UnknownColumns) > 0 {
+			log.Printf("Ignoring unknown worksheet column(s): %s", strings.Join(worksheet.UnknownColumns, ", "))
+		}
+		if crossCheck != nil {
+			disagreements := app.CrossCheck(worksheet, crossCheck)
+			for _, d := range disagreements {
+				log.Printf("%s disagrees with %s on %s", *crossCheckName, *converterName, d)
+			}
+			log.Printf("Cross-check complete: %d disagreement(s)", len(disagreements))
+		}
+		return worksheet, nil
+	}
+
 	submissionReader, err := file.NewReader[[]app.Submission](*responsesFile)
 	if err != nil {
 		log.Fatal(err)
 	}
-	submissions, err := submissionReader.Read(app.NewSubmissionList)
+	submissions, err := submissionReader.WithSheet(sheetOf(*responsesSheet)).Read(app.NewSubmissionList)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	results := app.GetResults(worksheet, submissions)
+	var results app.Results
+	if *variants {
+		source, _ := generatorSource()
+		worksheets := make([]app.Worksheet, 0, len(submissions))
+		for _, submission := range submissions {
+			rows, err := source(submission.StudentName)
+			if err != nil {
+				log.Fatal(err)
+			}
+			worksheet, err := prepare(rows)
+			if err != nil {
+				log.Fatal(err)
+			}
+			worksheets = append(worksheets, worksheet)
+		}
+		results, err = app.GetVariantResults(worksheets, submissions)
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		worksheet, err := readWorksheet(*worksheetFile, sheetOf(*worksheetSheet), prepare)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		discrepancies := worksheet.Discrepancies()
+		for _, d := range discrepancies {
+			log.Printf("Expected answer disagrees with computed answer on %s", d)
+		}
+		if *discrepancyReport != "" {
+			writeGrid(*discrepancyReport, app.DisagreementsToGrid(discrepancies, "Expected Answer", "Computed Answer"))
+			log.Printf("Discrepancy report with %d row(s) can be found here: %s", len(discrepancies), *discrepancyReport)
+		}
+		results = app.GetResults(worksheet, submissions)
+	}
+
+	for _, submission := range submissions {
+		if len(submission.UnmatchedIDs) > 0 {
+			log.Printf("Ignoring responses of %s to unknown question(s): %s",
+				submission.StudentName, strings.Join(submission.UnmatchedIDs, ", "))
+		}
+	}
 
 	resultData := results.ToGridDisplay()
-	outputWriter, err := file.NewWriter(*outputLocation)
+	if *writeBack {
+		outputWriter, err := file.NewWriter(*workbookFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = outputWriter.WithSheet(*resultsSheet).Write(resultData)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Success! Graded results can be found in sheet %s of: %s", *resultsSheet, *workbookFile)
+	}
+	if *outputLocation != "" {
+		writeGrid(*outputLocation, resultData)
+		log.Printf("Success! Graded results can be found here: %s", *outputLocation)
+	}
+}
+
+// isFlagSet reports whether a command line flag was given explicitly.
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// metadataSheet is the XLSX sheet that may hold worksheet metadata in place
+// of a metadata block above the questions.
+const metadataSheet = "Metadata"
+
+// readWorksheet reads the worksheet from the named sheet, or the first sheet
+// when sheet is empty, along with any metadata sheet of the workbook.
+func readWorksheet(path, sheet string, parseFunc func([][]string) (app.Worksheet, error)) (app.Worksheet, error) {
+	metadataRows, err := readMetadataSheet(path, sheet)
 	if err != nil {
-		log.Fatal(err)
+		return app.Worksheet{}, err
 	}
-	err = outputWriter.Write(resultData)
+	worksheetReader, err := file.NewReader[app.Worksheet](path)
 	if err != nil {
-		log.Fatal(err)
+		return app.Worksheet{}, err
+	}
+	return worksheetReader.WithSheet(sheet).Read(func(data [][]string) (app.Worksheet, error) {
+		return parseFunc(slices.Concat(metadataRows, data))
+	})
+}
+
+// readMetadataSheet reads the metadata sheet of a workbook whose worksheet is
+// on another sheet, returning no rows when there is none.
+func readMetadataSheet(path, sheet string) ([][]string, error) {
+	sheets, err := file.SheetNames(path)
+	if err != nil {
+		return nil, err
+	}
+	if sheet == "" && len(sheets) > 0 {
+		sheet = sheets[0]
+	}
+	if sheet == metadataSheet || !slices.Contains(sheets, metadataSheet) {
+		return nil, nil
 	}
 
-	log.Printf("Success! Graded results can be found here: %s", *outputLocation)
+	metadataReader, err := file.NewReader[[][]string](path)
+	if err != nil {
+		return nil, err
+	}
+	return metadataReader.WithSheet(metadataSheet).Read(func(data [][]string) ([][]string, error) {
+		return data, nil
+	})
 }
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dougdoenges/flexion-coding-challenge/internal/app"
+	"github.com/dougdoenges/flexion-coding-challenge/internal/parser/file"
+)
+
+func runGenerate(args []string) {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	outputLocation := flags.String("output", "", "Give file path and name for the generated worksheet (required)")
+	studentsFile := flags.String("students", "", "Give file path for a roster of student names or IDs to generate one variant each")
+	generatorSource := registerGeneratorFlags(flags, time.Now().UnixNano())
+	flags.Parse(args)
+
+	if *outputLocation == "" {
+		log.Fatal("output file is required")
+	}
+	source, seed := generatorSource()
+
+	if *studentsFile == "" {
+		rows, err := source("")
+		if err != nil {
+			log.Fatal(err)
+		}
+		writeGrid(*outputLocation, rows)
+		log.Printf("Success! Worksheet generated with seed %d can be found here: %s", seed, *outputLocation)
+		return
+	}
+
+	rosterReader, err := file.NewReader[[]string](*studentsFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	roster, err := rosterReader.Read(app.NewRoster)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// names that slug alike would overwrite each other's variant
+	paths := make([]string, len(roster))
+	students := make(map[string]string)
+	for idx, student := range roster {
+		path, err := variantPath(*outputLocation, student)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if other, ok := students[path]; ok {
+			log.Fatalf("students '%s' and '%s' would both be written to %s", other, student, path)
+		}
+		students[path] = student
+		paths[idx] = path
+	}
+	for idx, student := range roster {
+		rows, err := source(student)
+		if err != nil {
+			log.Fatal(err)
+		}
+		writeGrid(paths[idx], rows)
+	}
+	log.Printf("Success! %d worksheet variants generated with seed %d can be found next to: %s",
+		len(roster), seed, *outputLocation)
+}
+
+// worksheetSource produces the worksheet rows of a student's variant, or of
+// the shared worksheet when student is empty.
+type worksheetSource func(student string) ([][]string, error)
+
+// registerGeneratorFlags adds the worksheet generation flags to flags and
+// returns a func reading them once parsed. Worksheets are expanded from the
+// template when one is given, and generated at random otherwise.
+func registerGeneratorFlags(flags *flag.FlagSet, defaultSeed int64) func() (worksheetSource, int64) {
+	dimensions := flags.String("dimensions", strings.Join(app.DefaultDimensions, ","), "Give comma separated dimensions to include")
+	units := flags.String("units", "", "Give comma separated units to use (default all units of the dimensions)")
+	count := flags.Int("count", 10, "Give number of questions")
+	minValue := flags.Float64("min", 0, "Give smallest input value")
+	maxValue := flags.Float64("max", 100, "Give largest input value")
+	decimals := flags.Int("decimals", 0, "Give decimal places of input values")
+	seed := flags.Int64("seed", defaultSeed, "Give random seed to reproduce a worksheet")
+	templateFile := flags.String("template", "", "Give file path for a worksheet template with {rand} and {choose} placeholders to expand instead")
+
+	return func() (worksheetSource, int64) {
+		if *templateFile != "" {
+			template := readRows(*templateFile)
+			return func(student string) ([][]string, error) {
+				if student == "" {
+					return app.ExpandTemplate(template, *seed)
+				}
+				return app.ExpandTemplateVariant(template, *seed, student)
+			}, *seed
+		}
+
+		opts := app.GeneratorOptions{
+			Dimensions: splitList(*dimensions),
+			Units:      splitList(*units),
+			Count:      *count,
+			Min:        *minValue,
+			Max:        *maxValue,
+			Decimals:   *decimals,
+			Seed:       *seed,
+		}
+		return func(student string) ([][]string, error) {
+			if student == "" {
+				return app.GenerateWorksheet(opts)
+			}
+			return app.GenerateVariant(opts, student)
+		}, *seed
+	}
+}
+
+// readRows reads a file's rows as they are.
+func readRows(path string) [][]string {
+	reader, err := file.NewReader[[][]string](path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	rows, err := reader.Read(func(data [][]string) ([][]string, error) {
+		return data, nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	return rows
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// variantPath names a student's worksheet after the output path,
+// e.g. worksheet.csv becomes worksheet-doug-doenges.csv.
+func variantPath(path, student string) (string, error) {
+	ext := filepath.Ext(path)
+	slug := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(student), "-"), "-")
+	if slug == "" {
+		return "", fmt.Errorf("student '%s' has no letters or digits to name their worksheet after", student)
+	}
+	return fmt.Sprintf("%s-%s%s", strings.TrimSuffix(path, ext), slug, ext), nil
+}
+
+func writeGrid(path string, rows [][]string) {
+	outputWriter, err := file.NewWriter(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = outputWriter.Write(rows)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// splitList splits a comma separated flag value, ignoring empty entries.
+func splitList(s string) []string {
+	list := make([]string, 0)
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
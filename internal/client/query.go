@@ -0,0 +1,44 @@
+package client
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/dougdoenges/flexion-coding-challenge/internal/app"
+)
+
+// runQuery answers one conversion question asked in plain English.
+func runQuery(args []string) {
+	flags := flag.NewFlagSet("query", flag.ExitOnError)
+	converterName := flags.String("converter", app.DefaultConverterName, "Give conversion backend used to answer")
+	roundingStr := flags.String("rounding", app.DefaultRounding.String(), "Give rounding of the answer, e.g. 2dp, 3sf or auto")
+	flags.Parse(args)
+
+	question := strings.Join(flags.Args(), " ")
+	if question == "" {
+		log.Fatal(`question is required, e.g. query "How many cups are in 3 liters?"`)
+	}
+
+	rounding, err := app.ParseRounding(*roundingStr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	converter, err := app.GetConverter(*converterName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	problem, err := app.ParseWordProblem(question)
+	if err != nil {
+		log.Fatal(err)
+	}
+	q, err := problem.ToQuestion(converter, rounding)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// input, from unit, to unit and answer key as the results show them
+	grid := q.ToGrid()
+	fmt.Printf("%s %s = %s %s\n", grid[0], grid[1], grid[3], grid[2])
+}
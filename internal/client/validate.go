@@ -0,0 +1,57 @@
+package client
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+
+	"github.com/dougdoenges/flexion-coding-challenge/internal/app"
+	"github.com/dougdoenges/flexion-coding-challenge/internal/parser/file"
+)
+
+func runValidate(args []string) {
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+	worksheetFile := flags.String("worksheet", "", "Give file path for worksheet to check (required)")
+	flags.Parse(args)
+
+	if *worksheetFile == "" {
+		log.Fatal("worksheet file path is required")
+	}
+
+	metadataRows, err := readMetadataSheet(*worksheetFile, "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	worksheetReader, err := file.NewReader[[]app.Diagnostic](*worksheetFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	diagnostics, err := worksheetReader.Read(func(data [][]string) ([]app.Diagnostic, error) {
+		return app.LintWorksheet(slices.Concat(metadataRows, data)), nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// rows of the metadata sheet come first, numbered on their own sheet
+	errorCount := 0
+	for _, d := range diagnostics {
+		location := *worksheetFile
+		if d.Row <= len(metadataRows) {
+			location += "[" + metadataSheet + "]"
+		} else {
+			d.Row -= len(metadataRows)
+		}
+		fmt.Printf("%s:%s\n", location, d)
+		if d.Severity == app.SeverityError {
+			errorCount++
+		}
+	}
+
+	log.Printf("Checked %s: %d error(s), %d warning(s)", *worksheetFile, errorCount, len(diagnostics)-errorCount)
+	if errorCount > 0 {
+		os.Exit(1)
+	}
+}
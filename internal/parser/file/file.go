@@ -39,6 +39,7 @@ func validFileTypes() []FileType {
 type Reader[T any] struct {
 	path     string
 	fileType FileType
+	sheet    string // XLSX sheet to read, the first sheet when empty
 }
 
 func NewReader[T any](path string) (Reader[T], error) {
@@ -47,7 +48,13 @@ func NewReader[T any](path string) (Reader[T], error) {
 		return Reader[T]{}, fmt.Errorf("invalid file given '%s'. allowed types: %s",
 			path, validFileTypes())
 	}
-	return Reader[T]{path, FileType(typ)}, nil
+	return Reader[T]{path, FileType(typ), ""}, nil
+}
+
+// WithSheet returns a reader of the named sheet of an XLSX workbook.
+func (r Reader[T]) WithSheet(name string) Reader[T] {
+	r.sheet = name
+	return r
 }
 
 func (r Reader[T]) Read(parseFunc func([][]string) (T, error)) (T, error) {
@@ -58,7 +65,7 @@ func (r Reader[T]) Read(parseFunc func([][]string) (T, error)) (T, error) {
 	case CSV:
 		gridValues, err = readCSV(r.path)
 	case EXCEL:
-		gridValues, err = readExcel(r.path)
+		gridValues, err = readExcel(r.path, r.sheet)
 	default:
 		return util.ZeroValue[T](), fmt.Errorf("unsupported file type: %s", r.fileType)
 	}
@@ -82,23 +89,41 @@ func readCSV(path string) ([][]string, error) {
 	defer file.Close()
 
 	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1 // rows may have different lengths, e.g. metadata rows
 	return reader.ReadAll()
 }
 
-func readExcel(filePath string) ([][]string, error) {
+func readExcel(filePath, sheetName string) ([][]string, error) {
 	f, err := excelize.OpenFile(filePath)
 	if err != nil {
 		return nil, err
 	}
 	defer f.Close()
 
-	sheetName := f.GetSheetName(0)
+	if sheetName == "" {
+		sheetName = f.GetSheetName(0)
+	}
 	return f.GetRows(sheetName)
 }
 
+// SheetNames lists the sheets of an XLSX workbook. Other file types have none.
+func SheetNames(path string) ([]string, error) {
+	if FileType(strings.ToLower(filepath.Ext(path))) != EXCEL {
+		return nil, nil
+	}
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return f.GetSheetList(), nil
+}
+
 type Writer struct {
 	path     string
 	fileType FileType
+	sheet    string // XLSX sheet to add to an existing workbook, a new workbook when empty
 }
 
 // NewWriter creates a new Writer instance based on the file type
@@ -107,7 +132,14 @@ func NewWriter(path string) (Writer, error) {
 	if !IsValidFileType(typ) {
 		return Writer{}, fmt.Errorf("invalid file type '%s'. Supported types: %s, %s", typ, CSV, EXCEL)
 	}
-	return Writer{path, FileType(typ)}, nil
+	return Writer{path, FileType(typ), ""}, nil
+}
+
+// WithSheet returns a writer that adds the data to an XLSX workbook as the
+// named sheet, replacing any sheet of that name and keeping the others.
+func (w Writer) WithSheet(name string) Writer {
+	w.sheet = name
+	return w
 }
 
 func (w Writer) Write(data [][]string) error {
@@ -115,6 +147,9 @@ func (w Writer) Write(data [][]string) error {
 	case CSV:
 		return writeCSVData(w.path, data)
 	case EXCEL:
+		if w.sheet != "" {
+			return writeExcelSheet(w.path, w.sheet, data)
+		}
 		return writeExcelData(w.path, data)
 	default:
 		return fmt.Errorf("unsupported file type '%s'", w.fileType)
@@ -143,20 +178,52 @@ func writeCSVData(path string, data [][]string) error {
 func writeExcelData(path string, data [][]string) error {
 	f := excelize.NewFile()
 
+	if err := setExcelRows(f, "Sheet1", data); err != nil {
+		return err
+	}
+
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("failed to save Excel file: %v", err)
+	}
+	return nil
+}
+
+func writeExcelSheet(path, sheet string, data [][]string) error {
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if idx, _ := f.GetSheetIndex(sheet); idx != -1 {
+		if err := f.DeleteSheet(sheet); err != nil {
+			return fmt.Errorf("failed to replace sheet '%s': %v", sheet, err)
+		}
+	}
+	if _, err := f.NewSheet(sheet); err != nil {
+		return fmt.Errorf("failed to add sheet '%s': %v", sheet, err)
+	}
+	if err := setExcelRows(f, sheet, data); err != nil {
+		return err
+	}
+
+	if err := f.Save(); err != nil {
+		return fmt.Errorf("failed to save Excel file: %v", err)
+	}
+	return nil
+}
+
+func setExcelRows(f *excelize.File, sheet string, data [][]string) error {
 	for i, row := range data {
 		for j, value := range row {
 			cell, err := excelize.CoordinatesToCellName(j+1, i+1)
 			if err != nil {
 				return fmt.Errorf("failed to convert coordinates to cell: %v", err)
 			}
-			if err := f.SetCellValue("Sheet1", cell, value); err != nil {
+			if err := f.SetCellValue(sheet, cell, value); err != nil {
 				return fmt.Errorf("failed to set cell value: %v", err)
 			}
 		}
 	}
-
-	if err := f.SaveAs(path); err != nil {
-		return fmt.Errorf("failed to save Excel file: %v", err)
-	}
 	return nil
 }
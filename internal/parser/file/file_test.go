@@ -107,6 +107,84 @@ func TestReader_ReadExcel(t *testing.T) {
 	}
 }
 
+func TestReader_ReadCSVRaggedRows(t *testing.T) {
+	content := [][]string{{"Title", "Quiz"}, {"Input", "From Unit", "To Unit"}}
+	filePath, err := createTempCSV(content)
+	if err != nil {
+		t.Fatalf("Failed to create temp CSV: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	r, err := NewReader[int](filePath)
+	if err != nil {
+		t.Fatalf("Failed to create reader: %v", err)
+	}
+
+	result, err := r.Read(func(data [][]string) (int, error) {
+		return len(data[0]) + len(data[1]), nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != 5 {
+		t.Errorf("Expected 5 cells, got %d", result)
+	}
+}
+
+func TestReader_ReadExcelSheet(t *testing.T) {
+	filePath, err := createTempExcel([][]string{{"Name", "Age"}, {"Alice", "30"}})
+	if err != nil {
+		t.Fatalf("Failed to create temp Excel: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	f, err := excelize.OpenFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to open temp Excel: %v", err)
+	}
+	f.NewSheet("Metadata")
+	f.SetCellValue("Metadata", "A1", "Title")
+	f.SetCellValue("Metadata", "B1", "Quiz")
+	if err := f.Save(); err != nil {
+		t.Fatalf("Failed to save temp Excel: %v", err)
+	}
+	f.Close()
+
+	sheets, err := SheetNames(filePath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sheets) != 2 || sheets[1] != "Metadata" {
+		t.Errorf("Expected sheets [Sheet1 Metadata], got %v", sheets)
+	}
+
+	r, err := NewReader[string](filePath)
+	if err != nil {
+		t.Fatalf("Failed to create reader: %v", err)
+	}
+	parseFunc := func(data [][]string) (string, error) {
+		return data[0][1], nil
+	}
+
+	result, err := r.WithSheet("Metadata").Read(parseFunc)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != "Quiz" {
+		t.Errorf("Expected 'Quiz', got %s", result)
+	}
+
+	_, err = r.WithSheet("Missing").Read(parseFunc)
+	if err == nil {
+		t.Fatal("Expected error for missing sheet, got nil")
+	}
+
+	sheets, err = SheetNames("worksheet.csv")
+	if err != nil || sheets != nil {
+		t.Errorf("Expected no sheets for CSV, got %v, %v", sheets, err)
+	}
+}
+
 func TestReader_InvalidFileType(t *testing.T) {
 	filePath := "invalid.txt"
 	_, err := NewReader[string](filePath)
@@ -222,3 +300,48 @@ func TestWriter_InvalidFileType(t *testing.T) {
 		t.Fatal("Expected error for invalid file type, got nil")
 	}
 }
+
+func TestWriter_WriteExcelSheet(t *testing.T) {
+	filePath, err := createTempExcel([][]string{{"Name", "Age"}, {"Alice", "30"}})
+	if err != nil {
+		t.Fatalf("Failed to create temp Excel: %v", err)
+	}
+	defer os.Remove(filePath)
+
+	w, err := NewWriter(filePath)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	if err := w.WithSheet("Results").Write([][]string{{"old"}}); err != nil {
+		t.Fatalf("Failed to write Excel sheet: %v", err)
+	}
+	// writing again replaces the sheet
+	if err := w.WithSheet("Results").Write([][]string{{"Score", "100%"}}); err != nil {
+		t.Fatalf("Failed to write Excel sheet: %v", err)
+	}
+
+	sheets, err := SheetNames(filePath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sheets) != 2 || sheets[0] != "Sheet1" || sheets[1] != "Results" {
+		t.Errorf("Expected sheets [Sheet1 Results], got %v", sheets)
+	}
+
+	r, err := NewReader[[][]string](filePath)
+	if err != nil {
+		t.Fatalf("Failed to create reader: %v", err)
+	}
+	identity := func(data [][]string) ([][]string, error) {
+		return data, nil
+	}
+
+	original, err := r.Read(identity)
+	if err != nil || original[1][0] != "Alice" {
+		t.Errorf("Expected the original sheet to be kept, got %v, %v", original, err)
+	}
+	results, err := r.WithSheet("Results").Read(identity)
+	if err != nil || len(results) != 1 || results[0][1] != "100%" {
+		t.Errorf("Expected the written sheet, got %v, %v", results, err)
+	}
+}
@@ -0,0 +1,14 @@
+// Package quantity makes the grading engine's unit-aware quantities available
+// to code outside this module, which cannot import internal/app.
+package quantity
+
+import "github.com/dougdoenges/flexion-coding-challenge/internal/app"
+
+// Quantity is a value carrying its unit, converted and combined with the
+// grader's conversion tables. An empty unit is a dimensionless number.
+type Quantity = app.Quantity
+
+// New returns a quantity of a known unit, or of no unit.
+func New(val float64, unit string) (Quantity, error) {
+	return app.NewQuantity(val, unit)
+}
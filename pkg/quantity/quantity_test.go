@@ -0,0 +1,25 @@
+package quantity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuantity(t *testing.T) {
+	gallon, err := New(1, "Gallons")
+	assert.NoError(t, err)
+
+	cups, err := gallon.To("cups")
+	assert.NoError(t, err)
+	assert.Equal(t, Quantity{Value: 16, Unit: "cups"}, cups)
+
+	rate, err := Quantity{Value: 30, Unit: "liters"}.Div(Quantity{Value: 2, Unit: "minutes"})
+	assert.NoError(t, err)
+	assert.Equal(t, "15 liters per minute", rate.String())
+
+	_, err = gallon.Add(Quantity{Value: 1, Unit: "kelvin"})
+	assert.Error(t, err)
+	_, err = New(1, "furlongs")
+	assert.Error(t, err)
+}